@@ -0,0 +1,75 @@
+package gohex
+
+import (
+	"io"
+)
+
+// Structure with optional settings of raw binary data parsing
+type BinaryOptions struct {
+	Skip        uint32 // Number of leading input bytes to skip
+	Length      uint32 // Maximum number of bytes to load (0 means no limit)
+	TrimPadding bool   // Strip trailing padding bytes from loaded data (whole address units only)
+	Padding     byte   // Value of padding byte stripped when TrimPadding is set
+}
+
+// Method to parsing raw binary data and add into memory at base address
+func (m *Memory) ParseBinary(reader io.Reader, address uint32, opts BinaryOptions) error {
+	m.Clear()
+	m.parsingFlag = true
+	defer func() { m.parsingFlag = false }()
+	if opts.Skip > 0 {
+		_, err := io.CopyN(io.Discard, reader, int64(opts.Skip))
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	if opts.Length > 0 {
+		reader = io.LimitReader(reader, int64(opts.Length))
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if opts.TrimPadding {
+		data = trimPadding(data, opts.Padding, m.addressUnit)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return m.AddBinary(address, data)
+}
+
+// Method to dumping binary data from memory range (gaps filled with padding byte)
+func (m *Memory) DumpBinary(writer io.Writer, address uint32, size uint32, padding byte) error {
+	unit := uint64(m.addressUnit)
+	current := uint64(address)
+	end := current + uint64(size)
+	for _, s := range m.dataSegments {
+		segStart := uint64(s.Address)
+		segEnd := segStart + uint64(m.segmentSize(s))
+		if segEnd <= current {
+			continue
+		}
+		if segStart >= end {
+			break
+		}
+		if segStart > current {
+			err := writePadding(writer, (segStart-current)*unit, padding)
+			if err != nil {
+				return err
+			}
+			current = segStart
+		}
+		if segEnd > end {
+			segEnd = end
+		}
+		_, err := writer.Write(s.Data[(current-segStart)*unit : (segEnd-segStart)*unit])
+		if err != nil {
+			return err
+		}
+		current = segEnd
+	}
+	return writePadding(writer, (end-current)*unit, padding)
+}
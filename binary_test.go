@@ -0,0 +1,97 @@
+package gohex
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseBinary(t *testing.T) {
+	m := NewMemory()
+	input := []byte{0xAA, 0xBB, 1, 2, 3, 4, 0xFF, 0xFF, 0xFF, 5}
+
+	err := m.ParseBinary(bytes.NewReader(input), 0x8000, BinaryOptions{})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0x8000, Data: input}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	err = m.ParseBinary(bytes.NewReader(input), 0x8000, BinaryOptions{Skip: 2, Length: 7, TrimPadding: true, Padding: 0xFF})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if len(m.GetDataSegments()) != 1 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+	seg = m.GetDataSegments()[0]
+	p = DataSegment{Address: 0x8000, Data: []byte{1, 2, 3, 4}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	err = m.ParseBinary(bytes.NewReader(input), 0x8000, BinaryOptions{Skip: 20})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if len(m.GetDataSegments()) != 0 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+
+	err = m.ParseBinary(bytes.NewReader([]byte{0xFF, 0xFF}), 0, BinaryOptions{TrimPadding: true, Padding: 0xFF})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if len(m.GetDataSegments()) != 0 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+
+	m.SetAddressUnit(2, nil)
+	err = m.ParseBinary(bytes.NewReader([]byte{1, 2, 3, 0xFF, 0xFF, 0xFF}), 0x10, BinaryOptions{TrimPadding: true, Padding: 0xFF})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg = m.GetDataSegments()[0]
+	p = DataSegment{Address: 0x10, Data: []byte{1, 2, 3, 0xFF}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+}
+
+func TestDumpBinary(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x20000000, []byte{11, 12, 13, 14})
+	m.AddBinary(0xA, []byte{9, 10, 11, 12})
+	m.AddBinary(0x4, []byte{5, 6, 7, 8})
+
+	buf := bytes.Buffer{}
+	err := m.DumpBinary(&buf, 0, 16, 0xFF)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if reflect.DeepEqual(buf.Bytes(), m.ToBinary(0, 16, 0xFF)) == false {
+		t.Errorf("incorrect binary data: %v", buf.Bytes())
+	}
+
+	buf = bytes.Buffer{}
+	err = m.DumpBinary(&buf, 0x6, 6, 0)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	org := []byte{7, 8, 0, 0, 9, 10}
+	if reflect.DeepEqual(buf.Bytes(), org) == false {
+		t.Errorf("incorrect binary data: %v", buf.Bytes())
+	}
+
+	buf = bytes.Buffer{}
+	err = m.DumpBinary(&buf, 0x1FFFFF00, 0x200, 0)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if reflect.DeepEqual(buf.Bytes(), m.ToBinary(0x1FFFFF00, 0x200, 0)) == false {
+		t.Errorf("incorrect binary data: %v", buf.Bytes())
+	}
+}
@@ -0,0 +1,115 @@
+package gohex
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+)
+
+// Type of AES block cipher mode
+type CipherMode int
+
+// Constants definitions of AES block cipher modes
+const (
+	CipherCTR CipherMode = 0 // Counter mode (range is not aligned to block size)
+	CipherCBC CipherMode = 1 // Cipher block chaining mode
+)
+
+// Type of range end padding in CBC mode
+type BlockPadding int
+
+// Constants definitions of range end padding modes
+const (
+	BlockPaddingFill  BlockPadding = 0 // Range end aligned to block size with padding byte
+	BlockPaddingNone  BlockPadding = 1 // Range size must be multiple of block size
+	BlockPaddingPKCS7 BlockPadding = 2 // PKCS#7 padding added by encryption and removed by decryption
+)
+
+// Structure with settings of memory range encryption
+type EncryptionOptions struct {
+	Mode         CipherMode   // Block cipher mode
+	Key          []byte       // AES key (16, 24 or 32 bytes)
+	IV           []byte       // Initial vector or initial counter block (16 bytes)
+	BlockPadding BlockPadding // Padding of range end in CBC mode
+	Padding      byte         // Value of byte used to fill gaps (and align range end with fill padding)
+}
+
+func (m *Memory) cryptRange(address uint32, size uint32, opts EncryptionOptions, encrypt bool) error {
+	block, err := aes.NewCipher(opts.Key)
+	if err != nil {
+		return err
+	}
+	if len(opts.IV) != aes.BlockSize {
+		return errors.New("incorrect initial vector size")
+	}
+	if uint64(address)+uint64(size) > 0x100000000 {
+		return errors.New("range above 32-bit address space")
+	}
+	unit := m.addressUnit
+	switch opts.Mode {
+	case CipherCTR:
+		data := m.ToBinary(address, size, opts.Padding)
+		cipher.NewCTR(block, opts.IV).XORKeyStream(data, data)
+		return m.SetBinary(address, data)
+	case CipherCBC:
+	default:
+		return errors.New("incorrect cipher mode")
+	}
+
+	blockUnits := uint32(aes.BlockSize) / unit
+	padSize := uint32(0)
+	switch opts.BlockPadding {
+	case BlockPaddingFill:
+		padSize = (blockUnits - size%blockUnits) % blockUnits
+	case BlockPaddingNone:
+		if size%blockUnits != 0 {
+			return errors.New("range size not aligned to block size")
+		}
+	case BlockPaddingPKCS7:
+		if encrypt {
+			padSize = blockUnits - size%blockUnits
+		} else if size%blockUnits != 0 || size == 0 {
+			return errors.New("range size not aligned to block size")
+		}
+	default:
+		return errors.New("incorrect block padding")
+	}
+	if uint64(address)+uint64(size)+uint64(padSize) > 0x100000000 {
+		return errors.New("range above 32-bit address space")
+	}
+	if used, _ := m.Usage(address+size, padSize); encrypt == true && used != 0 {
+		return errors.New("block padding overlaps data outside range")
+	}
+	data := m.ToBinary(address, size+padSize, opts.Padding)
+	if opts.BlockPadding == BlockPaddingPKCS7 && encrypt {
+		pad := int(padSize * unit)
+		copy(data[len(data)-pad:], bytes.Repeat([]byte{byte(pad)}, pad))
+	}
+
+	if encrypt {
+		cipher.NewCBCEncrypter(block, opts.IV).CryptBlocks(data, data)
+		return m.SetBinary(address, data)
+	}
+	cipher.NewCBCDecrypter(block, opts.IV).CryptBlocks(data, data)
+	if opts.BlockPadding == BlockPaddingPKCS7 {
+		pad := int(data[len(data)-1])
+		if pad == 0 || pad > aes.BlockSize || pad%int(unit) != 0 ||
+			bytes.Equal(data[len(data)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) == false {
+			return errors.New("incorrect PKCS#7 padding")
+		}
+		data = data[:len(data)-pad]
+		m.RemoveBinary(address+uint32(len(data))/unit, uint32(pad)/unit)
+	}
+	return m.SetBinary(address, data)
+}
+
+// Method to encrypt memory range in place (gaps are filled with padding byte, CBC range grows up to block size into area without data)
+func (m *Memory) Encrypt(address uint32, size uint32, opts EncryptionOptions) error {
+	return m.cryptRange(address, size, opts, true)
+}
+
+// Method to decrypt memory range in place (gaps are filled with padding byte, CBC fill padded range end is read up to block size, PKCS#7 padding is removed)
+func (m *Memory) Decrypt(address uint32, size uint32, opts EncryptionOptions) error {
+	return m.cryptRange(address, size, opts, false)
+}
@@ -0,0 +1,173 @@
+package gohex
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestEncrypt(t *testing.T) {
+	key, _ := hex.DecodeString("2b7e151628aed2a6abf7158809cf4f3c")
+	plain, _ := hex.DecodeString("6bc1bee22e409f96e93d7e117393172a")
+	cbcIV, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	cbcOut, _ := hex.DecodeString("7649abac8119b246cee98e9b12e9197d")
+	ctrIV, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff")
+	ctrOut, _ := hex.DecodeString("874d6191b620e3261bef6864990db6ce")
+
+	for _, test := range []struct {
+		opts EncryptionOptions
+		out  []byte
+	}{
+		{EncryptionOptions{Mode: CipherCBC, Key: key, IV: cbcIV}, cbcOut},
+		{EncryptionOptions{Mode: CipherCTR, Key: key, IV: ctrIV}, ctrOut},
+	} {
+		m := NewMemory()
+		m.AddBinary(0x1000, plain)
+		err := m.Encrypt(0x1000, 16, test.opts)
+		if err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		data := m.ToBinary(0x1000, 16, 0)
+		if reflect.DeepEqual(data, test.out) == false {
+			t.Errorf("incorrect encrypted data: %x", data)
+		}
+		err = m.Decrypt(0x1000, 16, test.opts)
+		if err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		data = m.ToBinary(0x1000, 16, 0)
+		if reflect.DeepEqual(data, plain) == false {
+			t.Errorf("incorrect decrypted data: %x", data)
+		}
+	}
+}
+
+func TestEncryptGapsAndAlignment(t *testing.T) {
+	key := make([]byte, 32)
+	iv := make([]byte, 16)
+	opts := EncryptionOptions{Mode: CipherCBC, Key: key, IV: iv, Padding: 0xFF}
+
+	m := NewMemory()
+	m.AddBinary(0x00, []byte{1, 2, 3})
+	m.AddBinary(0x08, []byte{4, 5, 6, 7, 8, 9, 10, 11, 12, 13})
+	err := m.Encrypt(0, 18, opts)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	segs := m.GetDataSegments()
+	if len(segs) != 1 || segs[0].Address != 0 || len(segs[0].Data) != 32 {
+		t.Errorf("incorrect encrypted segments: %v", segs)
+	}
+	err = m.Decrypt(0, 32, opts)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	data := m.ToBinary(0, 32, 0)
+	org := []byte{1, 2, 3, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect decrypted data: %v", data)
+	}
+
+	if err = m.Encrypt(0, 16, EncryptionOptions{Key: key[:5], IV: iv}); err == nil {
+		t.Error("no key size error")
+	}
+	if err = m.Encrypt(0, 16, EncryptionOptions{Key: key, IV: iv[:8]}); err == nil {
+		t.Error("no initial vector size error")
+	}
+	if err = m.Encrypt(0xFFFFFFF8, 4, opts); err == nil {
+		t.Error("no address space error")
+	}
+}
+
+func TestEncryptPadding(t *testing.T) {
+	key := make([]byte, 16)
+	iv := make([]byte, 16)
+
+	m := NewMemory()
+	m.AddBinary(0x00, []byte{1, 2, 3})
+	m.AddBinary(0x04, []byte{5})
+	opts := EncryptionOptions{Mode: CipherCTR, Key: key, IV: iv, Padding: 0xFF}
+	err := m.Encrypt(0, 5, opts)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	segs := m.GetDataSegments()
+	if len(segs) != 1 || segs[0].Address != 0 || len(segs[0].Data) != 5 {
+		t.Errorf("incorrect encrypted segments: %v", segs)
+	}
+	m.Decrypt(0, 5, opts)
+	if data := m.ToBinary(0, 5, 0); reflect.DeepEqual(data, []byte{1, 2, 3, 0xFF, 5}) == false {
+		t.Errorf("incorrect decrypted data: %v", data)
+	}
+
+	opts = EncryptionOptions{Mode: CipherCBC, Key: key, IV: iv, BlockPadding: BlockPaddingNone}
+	if err = m.Encrypt(0, 5, opts); err == nil {
+		t.Error("no block alignment error")
+	}
+
+	opts.BlockPadding = BlockPaddingPKCS7
+	for _, size := range []uint32{5, 16} {
+		m.Clear()
+		m.AddBinary(0x100, make([]byte, size))
+		err = m.Encrypt(0x100, size, opts)
+		if err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		segs = m.GetDataSegments()
+		if len(segs) != 1 || len(segs[0].Data) != int(size/16+1)*16 {
+			t.Errorf("incorrect encrypted segments: %v", segs)
+		}
+		err = m.Decrypt(0x100, uint32(len(segs[0].Data)), opts)
+		if err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		segs = m.GetDataSegments()
+		if len(segs) != 1 || reflect.DeepEqual(segs[0].Data, make([]byte, size)) == false {
+			t.Errorf("incorrect decrypted segments: %v", segs)
+		}
+	}
+	m.Clear()
+	m.AddBinary(0x100, make([]byte, 16))
+	if err = m.Decrypt(0x100, 16, opts); err == nil {
+		t.Error("no padding error")
+	}
+	if err = m.Decrypt(0x100, 5, opts); err == nil {
+		t.Error("no block alignment error")
+	}
+}
+
+func TestEncryptPaddingArea(t *testing.T) {
+	key := make([]byte, 16)
+	iv := make([]byte, 16)
+	plain := []byte("ABCDEFGHIJKLMNOPQRST")
+
+	m := NewMemory()
+	m.AddBinary(0x100, append([]byte{}, plain...))
+	opts := EncryptionOptions{Mode: CipherCBC, Key: key, IV: iv, Padding: 0xFF}
+	err := m.Encrypt(0x100, 20, opts)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	err = m.Decrypt(0x100, 20, opts)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	org := append(append([]byte{}, plain...), 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+	if data := m.ToBinary(0x100, 32, 0); reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect decrypted data: %v", data)
+	}
+
+	for _, padding := range []BlockPadding{BlockPaddingFill, BlockPaddingPKCS7} {
+		m.Clear()
+		m.AddBinary(0x100, append([]byte{}, plain...))
+		m.AddBinary(0x11A, []byte{0xAA})
+		opts.BlockPadding = padding
+		if err = m.Encrypt(0x100, 20, opts); err == nil {
+			t.Error("no padding area overlap error")
+		}
+		if data := m.ToBinary(0x100, 20, 0); reflect.DeepEqual(data, plain) == false {
+			t.Errorf("data changed by failed encryption: %v", data)
+		}
+	}
+}
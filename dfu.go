@@ -0,0 +1,376 @@
+package gohex
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Constants definitions of Nordic DFU init packet (protocol buffers field numbers and values)
+const (
+	_DFU_PACKET_COMMAND        = 1 // Packet.command field
+	_DFU_PACKET_SIGNED_COMMAND = 2 // Packet.signed_command field
+	_DFU_SIGNED_COMMAND        = 1 // SignedCommand.command field
+	_DFU_COMMAND_OP_CODE       = 1 // Command.op_code field
+	_DFU_COMMAND_INIT          = 2 // Command.init field
+	_DFU_INIT_FW_VERSION       = 1 // InitCommand.fw_version field
+	_DFU_INIT_HW_VERSION       = 2 // InitCommand.hw_version field
+	_DFU_INIT_SD_REQ           = 3 // InitCommand.sd_req field
+	_DFU_INIT_TYPE             = 4 // InitCommand.type field
+	_DFU_INIT_SD_SIZE          = 5 // InitCommand.sd_size field
+	_DFU_INIT_BL_SIZE          = 6 // InitCommand.bl_size field
+	_DFU_INIT_APP_SIZE         = 7 // InitCommand.app_size field
+	_DFU_INIT_HASH             = 8 // InitCommand.hash field
+	_DFU_INIT_IS_DEBUG         = 9 // InitCommand.is_debug field
+	_DFU_HASH_TYPE             = 1 // Hash.hash_type field
+	_DFU_HASH_VALUE            = 2 // Hash.hash field
+	_DFU_OP_CODE_INIT          = 1 // Init command operation code
+	_DFU_HASH_SHA256           = 3 // SHA-256 hash type
+)
+
+// Structure with firmware image of Nordic DFU package
+type DFUFirmware struct {
+	Memory    *Memory  // Firmware data (stored as binary from the lowest to the highest address)
+	FWVersion uint32   // Firmware version
+	HWVersion uint32   // Hardware version
+	SDReq     []uint32 // Required SoftDevice firmware IDs
+}
+
+// Structure with firmware images of Nordic DFU package (nil means image not present)
+type DFUPackage struct {
+	Application *DFUFirmware // Application image
+	Bootloader  *DFUFirmware // Bootloader image
+	SoftDevice  *DFUFirmware // SoftDevice image
+}
+
+// Structure with addresses of firmware binaries loaded from Nordic DFU package
+type DFUAddresses struct {
+	Application uint32 // Starting address of application image
+	Bootloader  uint32 // Starting address of bootloader image
+	SoftDevice  uint32 // Starting address of SoftDevice image
+}
+
+type dfuManifestEntry struct {
+	BinFile string `json:"bin_file"`
+	DatFile string `json:"dat_file"`
+}
+
+type dfuManifest struct {
+	Manifest struct {
+		Application          *dfuManifestEntry `json:"application,omitempty"`
+		Bootloader           *dfuManifestEntry `json:"bootloader,omitempty"`
+		SoftDevice           *dfuManifestEntry `json:"softdevice,omitempty"`
+		SoftDeviceBootloader *dfuManifestEntry `json:"softdevice_bootloader,omitempty"`
+		DFUVersion           float64           `json:"dfu_version,omitempty"`
+	} `json:"manifest"`
+}
+
+// Helper type for DFU firmware image kinds
+type dfuImage struct {
+	name      string
+	fwType    uint64
+	sizeField uint64
+	firmware  **DFUFirmware
+	entry     **dfuManifestEntry
+	address   uint32
+}
+
+func dfuImages(pkg *DFUPackage, manifest *dfuManifest, addresses DFUAddresses) []dfuImage {
+	return []dfuImage{
+		{"softdevice", 1, _DFU_INIT_SD_SIZE, &pkg.SoftDevice, &manifest.Manifest.SoftDevice, addresses.SoftDevice},
+		{"bootloader", 2, _DFU_INIT_BL_SIZE, &pkg.Bootloader, &manifest.Manifest.Bootloader, addresses.Bootloader},
+		{"application", 0, _DFU_INIT_APP_SIZE, &pkg.Application, &manifest.Manifest.Application, addresses.Application},
+	}
+}
+
+func appendProtoVarint(data []byte, field uint64, value uint64) []byte {
+	data = binary.AppendUvarint(data, field<<3)
+	return binary.AppendUvarint(data, value)
+}
+
+func appendProtoBytes(data []byte, field uint64, value []byte) []byte {
+	data = binary.AppendUvarint(data, field<<3|2)
+	data = binary.AppendUvarint(data, uint64(len(value)))
+	return append(data, value...)
+}
+
+// Function to calculate SHA-256 hash of firmware binary in byte order used by DFU init packet (little endian)
+func dfuHash(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	hash := make([]byte, len(sum))
+	for i := range sum {
+		hash[i] = sum[len(sum)-1-i]
+	}
+	return hash
+}
+
+func makeDFUInitPacket(fw *DFUFirmware, fwType uint64, sizeField uint64, data []byte) []byte {
+	init := appendProtoVarint([]byte{}, _DFU_INIT_FW_VERSION, uint64(fw.FWVersion))
+	init = appendProtoVarint(init, _DFU_INIT_HW_VERSION, uint64(fw.HWVersion))
+	if len(fw.SDReq) != 0 {
+		sdReq := []byte{}
+		for _, req := range fw.SDReq {
+			sdReq = binary.AppendUvarint(sdReq, uint64(req))
+		}
+		init = appendProtoBytes(init, _DFU_INIT_SD_REQ, sdReq)
+	}
+	init = appendProtoVarint(init, _DFU_INIT_TYPE, fwType)
+	init = appendProtoVarint(init, sizeField, uint64(len(data)))
+	hash := appendProtoVarint([]byte{}, _DFU_HASH_TYPE, _DFU_HASH_SHA256)
+	hash = appendProtoBytes(hash, _DFU_HASH_VALUE, dfuHash(data))
+	init = appendProtoBytes(init, _DFU_INIT_HASH, hash)
+	init = appendProtoVarint(init, _DFU_INIT_IS_DEBUG, 0)
+	command := appendProtoVarint([]byte{}, _DFU_COMMAND_OP_CODE, _DFU_OP_CODE_INIT)
+	command = appendProtoBytes(command, _DFU_COMMAND_INIT, init)
+	return appendProtoBytes([]byte{}, _DFU_PACKET_COMMAND, command)
+}
+
+// Helper type for protocol buffers message field
+type protoField struct {
+	number uint64
+	varint uint64
+	bytes  []byte
+}
+
+func parseProtoMessage(data []byte) ([]protoField, error) {
+	fields := []protoField{}
+	for len(data) != 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errors.New("incorrect init packet field key")
+		}
+		data = data[n:]
+		f := protoField{number: key >> 3}
+		switch key & 7 {
+		case 0:
+			f.varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return nil, errors.New("incorrect init packet varint field")
+			}
+			data = data[n:]
+		case 2:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return nil, errors.New("incorrect init packet bytes field")
+			}
+			f.bytes = data[n : n+int(size)]
+			data = data[n+int(size):]
+		default:
+			return nil, errors.New("unsupported init packet field type")
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func findProtoField(fields []protoField, number uint64) (protoField, bool) {
+	for _, f := range fields {
+		if f.number == number {
+			return f, true
+		}
+	}
+	return protoField{}, false
+}
+
+func parseDFUInitPacket(packet []byte, fwType uint64, sizeField uint64, data []byte) (*DFUFirmware, error) {
+	fields, err := parseProtoMessage(packet)
+	if err != nil {
+		return nil, err
+	}
+	command, ok := findProtoField(fields, _DFU_PACKET_COMMAND)
+	if ok == false {
+		signed, ok := findProtoField(fields, _DFU_PACKET_SIGNED_COMMAND)
+		if ok == false {
+			return nil, errors.New("no command in init packet")
+		}
+		fields, err = parseProtoMessage(signed.bytes)
+		if err != nil {
+			return nil, err
+		}
+		if command, ok = findProtoField(fields, _DFU_SIGNED_COMMAND); ok == false {
+			return nil, errors.New("no command in signed init packet")
+		}
+	}
+	fields, err = parseProtoMessage(command.bytes)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := findProtoField(fields, _DFU_COMMAND_OP_CODE); ok == false || f.varint != _DFU_OP_CODE_INIT {
+		return nil, errors.New("no init command in init packet")
+	}
+	init, ok := findProtoField(fields, _DFU_COMMAND_INIT)
+	if ok == false {
+		return nil, errors.New("no init command in init packet")
+	}
+	fields, err = parseProtoMessage(init.bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &DFUFirmware{SDReq: []uint32{}}
+	hash := []byte(nil)
+	for _, f := range fields {
+		switch f.number {
+		case _DFU_INIT_FW_VERSION:
+			fw.FWVersion = uint32(f.varint)
+		case _DFU_INIT_HW_VERSION:
+			fw.HWVersion = uint32(f.varint)
+		case _DFU_INIT_SD_REQ:
+			if f.bytes == nil {
+				fw.SDReq = append(fw.SDReq, uint32(f.varint))
+				continue
+			}
+			for reqs := f.bytes; len(reqs) != 0; {
+				req, n := binary.Uvarint(reqs)
+				if n <= 0 {
+					return nil, errors.New("incorrect init packet sd_req field")
+				}
+				fw.SDReq = append(fw.SDReq, uint32(req))
+				reqs = reqs[n:]
+			}
+		case _DFU_INIT_TYPE:
+			if f.varint != fwType {
+				return nil, fmt.Errorf("incorrect firmware type (%d != %d)", f.varint, fwType)
+			}
+		case sizeField:
+			if f.varint != uint64(len(data)) {
+				return nil, fmt.Errorf("incorrect firmware size (%d != %d)", f.varint, len(data))
+			}
+		case _DFU_INIT_HASH:
+			hashFields, err := parseProtoMessage(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			if t, ok := findProtoField(hashFields, _DFU_HASH_TYPE); ok == false || t.varint != _DFU_HASH_SHA256 {
+				return nil, errors.New("unsupported init packet hash type")
+			}
+			value, _ := findProtoField(hashFields, _DFU_HASH_VALUE)
+			hash = value.bytes
+		}
+	}
+	if hash == nil {
+		return nil, errors.New("no firmware hash in init packet")
+	}
+	if bytes.Equal(hash, dfuHash(data)) == false {
+		return nil, errors.New("incorrect firmware hash")
+	}
+	return fw, nil
+}
+
+func (fw *DFUFirmware) toBinary(padding byte) ([]byte, error) {
+	segs := fw.Memory.GetDataSegments()
+	if len(segs) == 0 {
+		return nil, errors.New("empty firmware image")
+	}
+	if fw.Memory.addressUnit != 1 {
+		return nil, errors.New("firmware image with multi-byte address unit")
+	}
+	last := segs[len(segs)-1]
+	data := bytes.Buffer{}
+	err := fw.Memory.DumpBinary(&data, segs[0].Address, last.Address+uint32(len(last.Data))-segs[0].Address, padding)
+	return data.Bytes(), err
+}
+
+func writeZipFile(archive *zip.Writer, name string, data []byte) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	return err
+}
+
+// Function to write Nordic DFU zip package with manifest, firmware binaries and init packets (gaps filled with padding)
+func WriteDFUPackage(writer io.Writer, pkg DFUPackage, padding byte) error {
+	manifest := dfuManifest{}
+	manifest.Manifest.DFUVersion = 0.5
+	archive := zip.NewWriter(writer)
+	for _, img := range dfuImages(&pkg, &manifest, DFUAddresses{}) {
+		fw := *img.firmware
+		if fw == nil {
+			continue
+		}
+		data, err := fw.toBinary(padding)
+		if err != nil {
+			return err
+		}
+		*img.entry = &dfuManifestEntry{BinFile: img.name + ".bin", DatFile: img.name + ".dat"}
+		err = writeZipFile(archive, img.name+".bin", data)
+		if err != nil {
+			return err
+		}
+		err = writeZipFile(archive, img.name+".dat", makeDFUInitPacket(fw, img.fwType, img.sizeField, data))
+		if err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+	err = writeZipFile(archive, "manifest.json", data)
+	if err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func readZipFile(archive *zip.Reader, name string) ([]byte, error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// Function to read Nordic DFU zip package and load firmware binaries into memories at given addresses
+func ReadDFUPackage(reader io.ReaderAt, size int64, addresses DFUAddresses) (DFUPackage, error) {
+	pkg := DFUPackage{}
+	archive, err := zip.NewReader(reader, size)
+	if err != nil {
+		return pkg, err
+	}
+	data, err := readZipFile(archive, "manifest.json")
+	if err != nil {
+		return pkg, err
+	}
+	manifest := dfuManifest{}
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return pkg, err
+	}
+	if manifest.Manifest.SoftDeviceBootloader != nil {
+		return pkg, errors.New("combined softdevice and bootloader image is not supported")
+	}
+	for _, img := range dfuImages(&pkg, &manifest, addresses) {
+		entry := *img.entry
+		if entry == nil {
+			continue
+		}
+		data, err := readZipFile(archive, entry.BinFile)
+		if err != nil {
+			return pkg, err
+		}
+		packet, err := readZipFile(archive, entry.DatFile)
+		if err != nil {
+			return pkg, err
+		}
+		fw, err := parseDFUInitPacket(packet, img.fwType, img.sizeField, data)
+		if err != nil {
+			return pkg, fmt.Errorf("%s init packet: %s", img.name, err.Error())
+		}
+		fw.Memory = NewMemory()
+		err = fw.Memory.ParseBinary(bytes.NewReader(data), img.address, BinaryOptions{})
+		if err != nil {
+			return pkg, err
+		}
+		*img.firmware = fw
+	}
+	return pkg, nil
+}
@@ -0,0 +1,101 @@
+package gohex
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDFUInitPacket(t *testing.T) {
+	fw := &DFUFirmware{FWVersion: 1, HWVersion: 52, SDReq: []uint32{0xFFFE}}
+	packet := makeDFUInitPacket(fw, 0, _DFU_INIT_APP_SIZE, []byte{1, 2, 3, 4})
+	org, _ := hex.DecodeString("0a3908011235080110341a03feff03200038044224080312206a806a9be8776c6e35c5b39fe701026f9b6c2947b4b6ab1f137fb9e147a7649f4800")
+	if reflect.DeepEqual(packet, org) == false {
+		t.Errorf("incorrect init packet: %x", packet)
+	}
+
+	parsed, err := parseDFUInitPacket(packet, 0, _DFU_INIT_APP_SIZE, []byte{1, 2, 3, 4})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	} else if reflect.DeepEqual(parsed, fw) == false {
+		t.Errorf("incorrect parsed init packet: %v", parsed)
+	}
+	if _, err = parseDFUInitPacket(packet, 0, _DFU_INIT_APP_SIZE, []byte{1, 2, 3, 5}); err == nil {
+		t.Error("no hash error")
+	}
+	if _, err = parseDFUInitPacket(packet, 0, _DFU_INIT_APP_SIZE, []byte{1, 2, 3}); err == nil {
+		t.Error("no size error")
+	}
+	if _, err = parseDFUInitPacket(packet, 2, _DFU_INIT_APP_SIZE, []byte{1, 2, 3, 4}); err == nil {
+		t.Error("no firmware type error")
+	}
+	if _, err = parseDFUInitPacket(packet[:10], 0, _DFU_INIT_APP_SIZE, []byte{1, 2, 3, 4}); err == nil {
+		t.Error("no truncated packet error")
+	}
+
+	signed := appendProtoBytes([]byte{}, _DFU_PACKET_SIGNED_COMMAND, append(appendProtoBytes([]byte{}, _DFU_SIGNED_COMMAND, packet[2:]), 0x10, 0x00))
+	if _, err = parseDFUInitPacket(signed, 0, _DFU_INIT_APP_SIZE, []byte{1, 2, 3, 4}); err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+}
+
+func TestDFUPackage(t *testing.T) {
+	app := NewMemory()
+	app.AddBinary(0x27000, []byte{1, 2, 3, 4})
+	app.AddBinary(0x27006, []byte{5, 6})
+	bl := NewMemory()
+	bl.AddBinary(0xF8000, []byte{7, 8, 9})
+
+	pkg := DFUPackage{
+		Application: &DFUFirmware{Memory: app, FWVersion: 3, HWVersion: 52, SDReq: []uint32{0x100, 0x101}},
+		Bootloader:  &DFUFirmware{Memory: bl, FWVersion: 2, HWVersion: 52, SDReq: []uint32{0x100}},
+	}
+	buf := bytes.Buffer{}
+	err := WriteDFUPackage(&buf, pkg, 0xFF)
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+	names := []string{}
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+	}
+	if strings.Join(names, " ") != "bootloader.bin bootloader.dat application.bin application.dat manifest.json" {
+		t.Errorf("incorrect package files: %v", names)
+	}
+	file, _ := archive.Open("manifest.json")
+	manifest, _ := io.ReadAll(file)
+	if strings.Contains(string(manifest), `"bin_file": "application.bin"`) == false || strings.Contains(string(manifest), "softdevice") {
+		t.Errorf("incorrect manifest: %s", manifest)
+	}
+
+	read, err := ReadDFUPackage(bytes.NewReader(buf.Bytes()), int64(buf.Len()), DFUAddresses{Application: 0x27000, Bootloader: 0xF8000})
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+	if read.SoftDevice != nil || read.Application == nil || read.Bootloader == nil {
+		t.Fatalf("incorrect package images: %v", read)
+	}
+	if read.Application.FWVersion != 3 || read.Application.HWVersion != 52 || reflect.DeepEqual(read.Application.SDReq, []uint32{0x100, 0x101}) == false {
+		t.Errorf("incorrect application init packet: %v", read.Application)
+	}
+	data := read.Application.Memory.ToBinary(0x27000, 8, 0x00)
+	if reflect.DeepEqual(data, []byte{1, 2, 3, 4, 0xFF, 0xFF, 5, 6}) == false {
+		t.Errorf("incorrect application data: %v", data)
+	}
+	if read.Bootloader.Memory.Equal(bl) == false {
+		t.Errorf("incorrect bootloader data: %v", read.Bootloader.Memory.GetDataSegments())
+	}
+
+	if err = WriteDFUPackage(&buf, DFUPackage{Application: &DFUFirmware{Memory: NewMemory()}}, 0xFF); err == nil {
+		t.Error("no empty firmware error")
+	}
+}
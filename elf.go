@@ -0,0 +1,46 @@
+package gohex
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"io"
+)
+
+// Method to parsing ELF object file loadable segments (placed at physical addresses) and add into memory
+func (m *Memory) ParseELF(reader io.Reader) error {
+	m.Clear()
+	m.parsingFlag = true
+	defer func() { m.parsingFlag = false }()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	file, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	for _, prog := range file.Progs {
+		if prog.Type != elf.PT_LOAD || prog.Filesz == 0 {
+			continue
+		}
+		if prog.Paddr+prog.Filesz > 0x100000000 {
+			return fmt.Errorf("segment at address 0x%X above 32-bit address space", prog.Paddr)
+		}
+		segment := make([]byte, prog.Filesz)
+		_, err = io.ReadFull(prog.Open(), segment)
+		if err != nil {
+			return err
+		}
+		err = m.AddBinary(uint32(prog.Paddr), segment)
+		if err != nil {
+			return err
+		}
+	}
+	if file.Entry > 0xFFFFFFFF {
+		return fmt.Errorf("entry point 0x%X above 32-bit address space", file.Entry)
+	}
+	m.SetStartAddress(uint32(file.Entry))
+	return nil
+}
@@ -0,0 +1,69 @@
+package gohex
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func makeTestELF(entry uint32, progs []elf.Prog32, data [][]byte) []byte {
+	header := elf.Header32{
+		Type:      uint16(elf.ET_EXEC),
+		Machine:   uint16(elf.EM_ARM),
+		Version:   uint32(elf.EV_CURRENT),
+		Entry:     entry,
+		Phoff:     52,
+		Ehsize:    52,
+		Phentsize: 32,
+		Phnum:     uint16(len(progs)),
+		Shentsize: 40,
+	}
+	copy(header.Ident[:], []byte{0x7F, 'E', 'L', 'F', byte(elf.ELFCLASS32), byte(elf.ELFDATA2LSB), byte(elf.EV_CURRENT)})
+	offset := uint32(52 + 32*len(progs))
+	for i := range progs {
+		progs[i].Off = offset
+		offset += progs[i].Filesz
+	}
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, header)
+	binary.Write(&buf, binary.LittleEndian, progs)
+	for _, d := range data {
+		buf.Write(d)
+	}
+	return buf.Bytes()
+}
+
+func TestParseELF(t *testing.T) {
+	file := makeTestELF(0x08000001, []elf.Prog32{
+		{Type: uint32(elf.PT_LOAD), Vaddr: 0x08000000, Paddr: 0x08000000, Filesz: 4, Memsz: 4},
+		{Type: uint32(elf.PT_LOAD), Vaddr: 0x20000000, Paddr: 0x08000004, Filesz: 2, Memsz: 2},
+		{Type: uint32(elf.PT_LOAD), Vaddr: 0x20000002, Paddr: 0x08000006, Filesz: 0, Memsz: 16},
+	}, [][]byte{{1, 2, 3, 4}, {5, 6}})
+
+	m := NewMemory()
+	err := m.ParseELF(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+	segs := m.GetDataSegments()
+	p := []DataSegment{{Address: 0x08000000, Data: []byte{1, 2, 3, 4, 5, 6}}}
+	if reflect.DeepEqual(segs, p) == false {
+		t.Errorf("incorrect segments: %v != %v", segs, p)
+	}
+	if adr, ok := m.GetStartAddress(); ok == false || adr != 0x08000001 {
+		t.Errorf("incorrect start address: %08X", adr)
+	}
+
+	file = makeTestELF(0, []elf.Prog32{
+		{Type: uint32(elf.PT_LOAD), Paddr: 0x100, Filesz: 4, Memsz: 4},
+		{Type: uint32(elf.PT_LOAD), Paddr: 0x102, Filesz: 2, Memsz: 2},
+	}, [][]byte{{1, 2, 3, 4}, {5, 6}})
+	if err = m.ParseELF(bytes.NewReader(file)); err == nil {
+		t.Error("no segments overlap error")
+	}
+	if err = m.ParseELF(bytes.NewReader(file[:20])); err == nil {
+		t.Error("no truncated file error")
+	}
+}
@@ -0,0 +1,136 @@
+package gohex
+
+import (
+	"sort"
+)
+
+// Structure with flash sector (erase unit) fields
+type FlashSector struct {
+	Address uint32 // Starting address of sector
+	Size    uint32 // Sector size in address units
+}
+
+// Structure with flash bank fields
+type FlashBank struct {
+	Name     string        // Name of bank
+	PageSize uint32        // Write page size in address units (0 means whole sector)
+	Sectors  []FlashSector // Sectors of bank sorted by address
+}
+
+// Structure describing flash memory layout of device
+type FlashLayout struct {
+	Banks []FlashBank // Flash banks of device
+}
+
+// Constructor of FlashBank structure with consecutive sectors of given sizes
+func NewFlashBank(name string, address uint32, pageSize uint32, sectorSizes ...uint32) FlashBank {
+	b := FlashBank{Name: name, PageSize: pageSize}
+	for _, size := range sectorSizes {
+		b.Sectors = append(b.Sectors, FlashSector{Address: address, Size: size})
+		address += size
+	}
+	return b
+}
+
+// Helper type for flash sectors sorting operations
+type sortSectorsByAddress []FlashSector
+
+func (secs sortSectorsByAddress) Len() int           { return len(secs) }
+func (secs sortSectorsByAddress) Swap(i, j int)      { secs[i], secs[j] = secs[j], secs[i] }
+func (secs sortSectorsByAddress) Less(i, j int) bool { return secs[i].Address < secs[j].Address }
+
+func (l *FlashLayout) sortedSectors() []FlashSector {
+	secs := []FlashSector{}
+	for _, b := range l.Banks {
+		secs = append(secs, b.Sectors...)
+	}
+	sort.Sort(sortSectorsByAddress(secs))
+	return secs
+}
+
+func (m *Memory) isRangeUsed(adr uint32, size uint32) bool {
+	for _, s := range m.dataSegments {
+		if m.isOverlap(s, adr, size) == true {
+			return true
+		}
+	}
+	return false
+}
+
+// Method to getting sectors which contain any data of memory
+func (l *FlashLayout) AffectedSectors(m *Memory) []FlashSector {
+	secs := []FlashSector{}
+	for _, sec := range l.sortedSectors() {
+		if m.isRangeUsed(sec.Address, sec.Size) {
+			secs = append(secs, sec)
+		}
+	}
+	return secs
+}
+
+// Method to getting copy of memory data placed outside of defined flash sectors
+func (l *FlashLayout) OutsideData(m *Memory) []DataSegment {
+	secs := l.sortedSectors()
+	segs := []DataSegment{}
+	unit := uint64(m.addressUnit)
+	for _, s := range m.dataSegments {
+		current := uint64(s.Address)
+		end := current + uint64(m.segmentSize(s))
+		for _, sec := range secs {
+			secStart := uint64(sec.Address)
+			secEnd := secStart + uint64(sec.Size)
+			if secEnd <= current {
+				continue
+			}
+			if secStart >= end {
+				break
+			}
+			if secStart > current {
+				segs = append(segs, DataSegment{Address: uint32(current), Data: append([]byte{}, s.Data[(current-uint64(s.Address))*unit:(secStart-uint64(s.Address))*unit]...)})
+			}
+			current = secEnd
+		}
+		if current < end {
+			segs = append(segs, DataSegment{Address: uint32(current), Data: append([]byte{}, s.Data[(current-uint64(s.Address))*unit:]...)})
+		}
+	}
+	return segs
+}
+
+func (m *Memory) fillRange(adr uint32, size uint32, fill byte) {
+	for _, g := range m.Gaps(adr, size) {
+		data := make([]byte, g.Size*m.addressUnit)
+		for i := range data {
+			data[i] = fill
+		}
+		m.AddBinary(g.Address, data)
+	}
+}
+
+// Method to pad data in memory out to boundaries of affected sectors
+func (l *FlashLayout) PadToSectors(m *Memory, fill byte) {
+	for _, sec := range l.AffectedSectors(m) {
+		m.fillRange(sec.Address, sec.Size, fill)
+	}
+}
+
+// Method to pad data in memory out to boundaries of affected write pages
+func (l *FlashLayout) PadToPages(m *Memory, fill byte) {
+	for _, b := range l.Banks {
+		for _, sec := range b.Sectors {
+			pageSize := b.PageSize
+			if pageSize == 0 || pageSize > sec.Size {
+				pageSize = sec.Size
+			}
+			for offset := uint32(0); offset < sec.Size; offset += pageSize {
+				size := pageSize
+				if sec.Size-offset < size {
+					size = sec.Size - offset
+				}
+				if m.isRangeUsed(sec.Address+offset, size) {
+					m.fillRange(sec.Address+offset, size, fill)
+				}
+			}
+		}
+	}
+}
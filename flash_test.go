@@ -0,0 +1,94 @@
+package gohex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testFlashLayout() *FlashLayout {
+	return &FlashLayout{Banks: []FlashBank{
+		NewFlashBank("bank1", 0x08000000, 0x100, 0x4000, 0x4000, 0x4000, 0x4000, 0x10000, 0x20000),
+	}}
+}
+
+func TestAffectedSectors(t *testing.T) {
+	l := testFlashLayout()
+	m := NewMemory()
+	m.AddBinary(0x08003FFE, []byte{1, 2, 3, 4})
+	m.AddBinary(0x08012000, []byte{5, 6})
+
+	secs := l.AffectedSectors(m)
+	org := []FlashSector{
+		{Address: 0x08000000, Size: 0x4000},
+		{Address: 0x08004000, Size: 0x4000},
+		{Address: 0x08010000, Size: 0x10000},
+	}
+	if reflect.DeepEqual(secs, org) == false {
+		t.Errorf("incorrect sectors: %v", secs)
+	}
+}
+
+func TestOutsideData(t *testing.T) {
+	l := testFlashLayout()
+	m := NewMemory()
+	m.AddBinary(0x07FFFFFE, []byte{1, 2, 3, 4})
+	m.AddBinary(0x0803FFFF, []byte{5, 6})
+	m.AddBinary(0x20000000, []byte{7})
+
+	segs := l.OutsideData(m)
+	org := []DataSegment{
+		{Address: 0x07FFFFFE, Data: []byte{1, 2}},
+		{Address: 0x08040000, Data: []byte{6}},
+		{Address: 0x20000000, Data: []byte{7}},
+	}
+	if reflect.DeepEqual(segs, org) == false {
+		t.Errorf("incorrect segments: %v", segs)
+	}
+
+	segs[0].Data[0] = 0xEE
+	if data := m.ToBinary(0x07FFFFFE, 1, 0); data[0] != 1 {
+		t.Errorf("outside data shared with memory: %v", data)
+	}
+}
+
+func TestPadToSectors(t *testing.T) {
+	l := testFlashLayout()
+	m := NewMemory()
+	m.AddBinary(0x08000010, []byte{1, 2, 3, 4})
+	m.AddBinary(0x08004000, []byte{5})
+
+	l.PadToSectors(m, 0xFF)
+
+	if len(m.GetDataSegments()) != 1 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+	seg := m.GetDataSegments()[0]
+	if seg.Address != 0x08000000 || len(seg.Data) != 0x8000 {
+		t.Errorf("incorrect segment: %08X %v", seg.Address, len(seg.Data))
+	}
+	data := m.ToBinary(0x0800000E, 8, 0)
+	org := []byte{0xFF, 0xFF, 1, 2, 3, 4, 0xFF, 0xFF}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+}
+
+func TestPadToPages(t *testing.T) {
+	l := testFlashLayout()
+	m := NewMemory()
+	m.AddBinary(0x08000010, []byte{1, 2, 3, 4})
+	m.AddBinary(0x08000250, []byte{5})
+
+	l.PadToPages(m, 0xFF)
+
+	segs := m.GetDataSegments()
+	if len(segs) != 2 {
+		t.Errorf("incorrect number of data segments: %v", len(segs))
+	}
+	if segs[0].Address != 0x08000000 || len(segs[0].Data) != 0x100 {
+		t.Errorf("incorrect segment: %08X %v", segs[0].Address, len(segs[0].Data))
+	}
+	if segs[1].Address != 0x08000200 || len(segs[1].Data) != 0x100 {
+		t.Errorf("incorrect segment: %08X %v", segs[1].Address, len(segs[1].Data))
+	}
+}
@@ -0,0 +1,164 @@
+package gohex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Type of input data format recognized by Load function
+type Format int
+
+// Constants definitions of recognized input data formats
+const (
+	FormatBinary            Format = 0 // Raw binary data
+	FormatIntelHex          Format = 1 // Intel HEX records
+	FormatSRecord           Format = 2 // Motorola S-records
+	FormatELF               Format = 3 // ELF object file
+	FormatUF2               Format = 4 // USB Flashing Format
+	FormatTITXT             Format = 5 // TI-TXT (MSP430) text data
+	FormatTektronix         Format = 6 // Tektronix hex records
+	FormatExtendedTektronix Format = 7 // Extended Tektronix hex records
+	FormatMOS               Format = 8 // MOS Technology records
+)
+
+// Constants definitions of magic values used by format detection
+const (
+	_UF2_MAGIC_START0 uint32 = 0x0A324655 // First UF2 block magic word ("UF2\n")
+	_UF2_MAGIC_START1 uint32 = 0x9E5D5157 // Second UF2 block magic word
+	_DETECT_SIZE             = 64         // Number of input bytes examined by format detection
+)
+
+var elfMagic = []byte{0x7F, 'E', 'L', 'F'}
+
+// Method to getting human readable name of format
+func (f Format) String() string {
+	switch f {
+	case FormatBinary:
+		return "binary"
+	case FormatIntelHex:
+		return "intelhex"
+	case FormatSRecord:
+		return "srecord"
+	case FormatELF:
+		return "elf"
+	case FormatUF2:
+		return "uf2"
+	case FormatTITXT:
+		return "titxt"
+	case FormatTektronix:
+		return "tektronix"
+	case FormatExtendedTektronix:
+		return "exttektronix"
+	case FormatMOS:
+		return "mos"
+	}
+	return fmt.Sprintf("format(%d)", int(f))
+}
+
+// Function to check that all bytes are printable text characters or whitespaces
+func isText(data []byte) bool {
+	for _, b := range data {
+		if (b < 0x20 || b > 0x7E) && b != '\t' && b != '\r' && b != '\n' {
+			return false
+		}
+	}
+	return true
+}
+
+// Function to check that text record starts with at least two hex digits
+func hasHexDigits(record []byte) bool {
+	if len(record) < 2 {
+		return false
+	}
+	for _, c := range record[:2] {
+		if (c < '0' || c > '9') && (c < 'A' || c > 'F') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Function to detect data format from the first bytes of input
+func detectFormat(head []byte) Format {
+	if bytes.HasPrefix(head, elfMagic) {
+		return FormatELF
+	}
+	if len(head) >= 8 &&
+		binary.LittleEndian.Uint32(head[0:4]) == _UF2_MAGIC_START0 &&
+		binary.LittleEndian.Uint32(head[4:8]) == _UF2_MAGIC_START1 {
+		return FormatUF2
+	}
+	if isText(head) == false {
+		return FormatBinary
+	}
+	text := bytes.TrimLeft(head, " \t\r\n")
+	if len(text) < 3 {
+		return FormatBinary
+	}
+	switch text[0] {
+	case ':':
+		if hasHexDigits(text[1:]) {
+			return FormatIntelHex
+		}
+	case '@':
+		if hasHexDigits(text[1:]) {
+			return FormatTITXT
+		}
+	case '/':
+		if hasHexDigits(text[1:]) {
+			return FormatTektronix
+		}
+	case '%':
+		if hasHexDigits(text[1:]) {
+			return FormatExtendedTektronix
+		}
+	case ';':
+		if hasHexDigits(text[1:]) {
+			return FormatMOS
+		}
+	case 'S':
+		if text[1] >= '0' && text[1] <= '9' && hasHexDigits(text[2:]) {
+			return FormatSRecord
+		}
+	}
+	return FormatBinary
+}
+
+// Function to load data of any supported format (detected from input content) into new memory
+func Load(reader io.Reader) (*Memory, Format, error) {
+	r := bufio.NewReader(reader)
+	head, err := r.Peek(_DETECT_SIZE)
+	if err != nil && err != io.EOF {
+		return nil, FormatBinary, err
+	}
+	format := detectFormat(head)
+
+	m := NewMemory()
+	switch format {
+	case FormatIntelHex:
+		err = m.ParseIntelHex(r)
+	case FormatTITXT:
+		err = m.ParseTITXT(r)
+	case FormatTektronix:
+		err = m.ParseTektronix(r)
+	case FormatExtendedTektronix:
+		err = m.ParseExtendedTektronix(r)
+	case FormatMOS:
+		err = m.ParseMOS(r)
+	case FormatSRecord:
+		err = m.ParseSRecord(r)
+	case FormatELF:
+		err = m.ParseELF(r)
+	case FormatUF2:
+		err = m.ParseUF2(r)
+	default:
+		err = m.ParseBinary(r, 0, BinaryOptions{})
+	}
+	if err != nil {
+		return nil, format, err
+	}
+	return m, format, nil
+}
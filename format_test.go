@@ -0,0 +1,135 @@
+package gohex
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	if f := detectFormat([]byte(":00000001FF")); f != FormatIntelHex {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte("@F000\n31 40")); f != FormatTITXT {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte("/01000203")); f != FormatTektronix {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte("%1261580")); f != FormatExtendedTektronix {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte(";030100010203000A")); f != FormatMOS {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte("S00600004844521B")); f != FormatSRecord {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte("S1130000")); f != FormatSRecord {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte{0x7F, 'E', 'L', 'F', 1, 1, 1, 0}); f != FormatELF {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte{'U', 'F', '2', '\n', 0x57, 0x51, 0x5D, 0x9E}); f != FormatUF2 {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte{'S', 'X', 0, 0}); f != FormatBinary {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte{}); f != FormatBinary {
+		t.Errorf("incorrect format: %v", f)
+	}
+	if f := detectFormat([]byte("\r\n\n  :00000001FF")); f != FormatIntelHex {
+		t.Errorf("incorrect format: %v", f)
+	}
+	for _, head := range [][]byte{
+		{'@', 0x00, 0x00, 0x20, 0x01, 0x01, 0x00, 0x08},
+		{':', 0x12, 0x34, 0x00},
+		{'%', 0xFF, 0xFF, 0xFF},
+		{'/', 0x00, 0x10, 0x00},
+		{';', 0x01, 0x02, 0x03},
+		[]byte(":GG00"),
+		[]byte("@X000"),
+		[]byte("S9"),
+	} {
+		if f := detectFormat(head); f != FormatBinary {
+			t.Errorf("incorrect format of %v: %v", head, f)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	m, f, err := Load(strings.NewReader(":048000000102030472\n:00000001FF\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if f != FormatIntelHex {
+		t.Errorf("incorrect format: %v", f)
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0x8000, Data: []byte{1, 2, 3, 4}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	m, f, err = Load(strings.NewReader("\x01\x02\x03"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if f != FormatBinary {
+		t.Errorf("incorrect format: %v", f)
+	}
+	seg = m.GetDataSegments()[0]
+	p = DataSegment{Address: 0, Data: []byte{1, 2, 3}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	_, f, err = Load(strings.NewReader(":00000001FE\n"))
+	checkErrorType(t, err, _CHECKSUM_ERROR, "no checksum error")
+	if f != FormatIntelHex {
+		t.Errorf("incorrect format: %v", f)
+	}
+
+	m, f, err = Load(strings.NewReader("\r\n\r\n:048000000102030472\r\n:00000001FF\r\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if f != FormatIntelHex {
+		t.Errorf("incorrect format: %v", f)
+	}
+	seg = m.GetDataSegments()[0]
+	p = DataSegment{Address: 0x8000, Data: []byte{1, 2, 3, 4}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	m, f, err = Load(strings.NewReader("S107100001020304DE\nS9031000EC\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if f != FormatSRecord {
+		t.Errorf("incorrect format: %v", f)
+	}
+	seg = m.GetDataSegments()[0]
+	p = DataSegment{Address: 0x1000, Data: []byte{1, 2, 3, 4}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	vectors := []byte{0x40, 0x00, 0x00, 0x20, 0x01, 0x01, 0x00, 0x08}
+	m, f, err = Load(bytes.NewReader(vectors))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if f != FormatBinary {
+		t.Errorf("incorrect format: %v", f)
+	}
+	seg = m.GetDataSegments()[0]
+	p = DataSegment{Address: 0, Data: vectors}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+}
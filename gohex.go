@@ -2,9 +2,13 @@ package gohex
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"io"
 	"sort"
+	"strings"
 )
 
 // Constants definitions of IntelHex record types
@@ -31,18 +35,27 @@ func (segs sortByAddress) Less(i, j int) bool { return segs[i].Address < segs[j]
 
 // Main structure with private fields of IntelHex parser
 type Memory struct {
-	dataSegments     []*DataSegment // Slice with pointers to DataSegments
-	startAddress     uint32         // Start linear address
-	extendedAddress  uint32         // Extended linear address
-	eofFlag          bool           // End of file record exist flag
-	startFlag        bool           // Start address record exist flag
-	lineNum          uint           // Parser input line number
-	firstAddressFlag bool           // Dump first address line
+	dataSegments     []*DataSegment    // Slice with pointers to DataSegments
+	startAddress     uint32            // Start linear address
+	extendedAddress  uint32            // Extended linear address
+	eofFlag          bool              // End of file record exist flag
+	startFlag        bool              // Start address record exist flag
+	lineNum          uint              // Parser input line number
+	firstAddressFlag bool              // Dump first address line
+	addressUnit      uint32            // Number of bytes per address
+	byteOrder        binary.ByteOrder  // Byte order of multi-byte address units
+	provenanceFlag   bool              // Provenance tracking enabled flag
+	provenance       []ProvenanceRange // Sorted address ranges with origin of data
+	sourceName       string            // Input source name recorded as provenance
+	parsingFlag      bool              // Input parsing in progress flag
+	transactions     []memorySnapshot  // Stack of snapshots of open transactions
 }
 
 // Constructor of Memory structure
 func NewMemory() *Memory {
 	m := new(Memory)
+	m.addressUnit = 1
+	m.byteOrder = binary.LittleEndian
 	m.Clear()
 	return m
 }
@@ -61,15 +74,76 @@ func (m *Memory) SetStartAddress(adr uint32) {
 	m.startFlag = true
 }
 
-// Method to getting data segments address from IntelHex data
+func (m *Memory) copySegments() []*DataSegment {
+	segs := make([]*DataSegment, 0, len(m.dataSegments))
+	for _, s := range m.dataSegments {
+		data := make([]byte, len(s.Data))
+		copy(data, s.Data)
+		segs = append(segs, &DataSegment{Address: s.Address, Data: data})
+	}
+	return segs
+}
+
+// Method to getting data segments address from IntelHex data (data bytes are copied)
 func (m *Memory) GetDataSegments() []DataSegment {
 	segs := []DataSegment{}
-	for _, s := range m.dataSegments {
+	for _, s := range m.copySegments() {
 		segs = append(segs, *s)
 	}
 	return segs
 }
 
+// Method to getting fully independent copy of memory
+func (m *Memory) Clone() *Memory {
+	c := NewMemory()
+	c.dataSegments = m.copySegments()
+	c.startAddress = m.startAddress
+	c.startFlag = m.startFlag
+	c.addressUnit = m.addressUnit
+	c.byteOrder = m.byteOrder
+	c.provenanceFlag = m.provenanceFlag
+	c.provenance = append([]ProvenanceRange{}, m.provenance...)
+	c.sourceName = m.sourceName
+	return c
+}
+
+func equalSegments(a []DataSegment, b []DataSegment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Address != b[i].Address || bytes.Equal(a[i].Data, b[i].Data) == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Method to compare data segments and start address of two memories
+func (m *Memory) Equal(other *Memory) bool {
+	if m.startFlag != other.startFlag || m.startAddress != other.startAddress || m.addressUnit != other.addressUnit {
+		return false
+	}
+	if len(m.dataSegments) != len(other.dataSegments) {
+		return false
+	}
+	for i, s := range m.dataSegments {
+		o := other.dataSegments[i]
+		if s.Address != o.Address || bytes.Equal(s.Data, o.Data) == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Method to compare data (including gaps) of two memories in address range
+func (m *Memory) EqualRange(other *Memory, address uint32, size uint32) bool {
+	if m.addressUnit != other.addressUnit {
+		return false
+	}
+	return equalSegments(m.segmentsInRange(address, size), other.segmentsInRange(address, size))
+}
+
 // Method to clear memory structure
 func (m *Memory) Clear() {
 	m.startAddress = 0
@@ -79,10 +153,15 @@ func (m *Memory) Clear() {
 	m.startFlag = false
 	m.eofFlag = false
 	m.firstAddressFlag = false
+	m.provenance = []ProvenanceRange{}
 }
 
-func (seg *DataSegment) isOverlap(adr uint32, size uint32) bool {
-	if ((adr >= seg.Address) && (adr < seg.Address+uint32(len(seg.Data)))) ||
+func (m *Memory) segmentSize(seg *DataSegment) uint32 {
+	return uint32(len(seg.Data)) / m.addressUnit
+}
+
+func (m *Memory) isOverlap(seg *DataSegment, adr uint32, size uint32) bool {
+	if ((adr >= seg.Address) && (adr < seg.Address+m.segmentSize(seg))) ||
 		((adr < seg.Address) && (adr+size) > seg.Address) {
 		return true
 	}
@@ -109,27 +188,96 @@ func (m *Memory) removeSegment(index int) {
 
 func (m *Memory) findDataSegment(adr uint32) (seg *DataSegment, offset uint32, index int) {
 	for i, s := range m.dataSegments {
-		if s.isOverlap(adr, 1) == true {
+		if m.isOverlap(s, adr, 1) == true {
 			return s, adr - s.Address, i
 		}
 	}
 	return nil, 0, 0
 }
 
+// Structure with address range fields
+type AddressRange struct {
+	Address uint32 // Starting address of range
+	Size    uint32 // Range size in address units
+}
+
+// Method to getting address ranges not occupied by data segments in address range
+func (m *Memory) Gaps(adr uint32, size uint32) []AddressRange {
+	gaps := []AddressRange{}
+	current := uint64(adr)
+	end := current + uint64(size)
+	for _, s := range m.dataSegments {
+		segStart := uint64(s.Address)
+		segEnd := segStart + uint64(m.segmentSize(s))
+		if segEnd <= current {
+			continue
+		}
+		if segStart >= end {
+			break
+		}
+		if segStart > current {
+			gaps = append(gaps, AddressRange{Address: uint32(current), Size: uint32(segStart - current)})
+		}
+		current = segEnd
+	}
+	if current < end {
+		gaps = append(gaps, AddressRange{Address: uint32(current), Size: uint32(end - current)})
+	}
+	return gaps
+}
+
+func (m *Memory) segmentsInRange(adr uint32, size uint32) []DataSegment {
+	segs := []DataSegment{}
+	unit := uint64(m.addressUnit)
+	start := uint64(adr)
+	end := start + uint64(size)
+	for _, s := range m.dataSegments {
+		segStart := uint64(s.Address)
+		segEnd := segStart + uint64(m.segmentSize(s))
+		if segEnd <= start {
+			continue
+		}
+		if segStart >= end {
+			break
+		}
+		from, to := segStart, segEnd
+		if from < start {
+			from = start
+		}
+		if to > end {
+			to = end
+		}
+		segs = append(segs, DataSegment{Address: uint32(from), Data: s.Data[(from-segStart)*unit : (to-segStart)*unit]})
+	}
+	return segs
+}
+
+func (m *Memory) lineStep(lineLength byte) (int, error) {
+	step := int(lineLength) - int(lineLength)%int(m.addressUnit)
+	if step == 0 {
+		return 0, errors.New("incorrect line length")
+	}
+	return step, nil
+}
+
 // Method to add binary data to memory (auto segmented and sorted)
 func (m *Memory) AddBinary(adr uint32, bytes []byte) error {
+	if uint32(len(bytes))%m.addressUnit != 0 {
+		return newParseError(_DATA_ERROR, "data not aligned to address unit", m.lineNum)
+	}
+	size := uint32(len(bytes)) / m.addressUnit
 	var segBefore *DataSegment = nil
 	var segAfter *DataSegment = nil
 	var segAfterIndex int
 	for i, s := range m.dataSegments {
-		if s.isOverlap(adr, uint32(len(bytes))) == true {
+		if m.isOverlap(s, adr, size) == true {
 			return newParseError(_DATA_ERROR, "data segments overlap", m.lineNum)
 		}
 
-		if adr == s.Address+uint32(len(s.Data)) {
+		if adr == s.Address+m.segmentSize(s) {
 			segBefore = s
 		}
-		if adr+uint32(len(bytes)) == s.Address {
+		if adr+size == s.Address {
 			segAfter, segAfterIndex = s, i
 		}
 	}
@@ -148,21 +296,34 @@ func (m *Memory) AddBinary(adr uint32, bytes []byte) error {
 		m.dataSegments = append(m.dataSegments, &DataSegment{Address: adr, Data: bytes})
 	}
 	sort.Sort(sortByAddress(m.dataSegments))
+	p := m.currentProvenance("AddBinary")
+	m.setProvenance(adr, size, &p)
 	return nil
 }
 
 // Method to set binary data to memory (data overlapped will change, auto segmented and sorted)
-func (m *Memory) SetBinary(adr uint32, bytes []byte) {
-	for a, b := range bytes {
-		currentAdr := adr + uint32(a)
+func (m *Memory) SetBinary(adr uint32, bytes []byte) error {
+	unit := m.addressUnit
+	if uint32(len(bytes))%unit != 0 {
+		return newParseError(_DATA_ERROR, "data not aligned to address unit", m.lineNum)
+	}
+	for a := uint32(0); a < uint32(len(bytes))/unit; a++ {
+		currentAdr := adr + a
+		b := bytes[a*unit : (a+1)*unit]
 		seg, offset, _ := m.findDataSegment(currentAdr)
 
 		if seg != nil {
-			seg.Data[offset] = b
+			copy(seg.Data[offset*unit:], b)
 		} else {
-			m.AddBinary(currentAdr, []byte{b})
+			err := m.AddBinary(currentAdr, append([]byte{}, b...))
+			if err != nil {
+				return err
+			}
 		}
 	}
+	p := m.currentProvenance("SetBinary")
+	m.setProvenance(adr, uint32(len(bytes))/unit, &p)
+	return nil
 }
 
 // Method to remove binary data from memory (auto segmented and sorted)
@@ -175,26 +336,61 @@ func (m *Memory) RemoveBinary(adr uint32, size uint32) {
 			continue
 		}
 
+		unit := m.addressUnit
 		if offset == 0 {
 			seg.Address += 1
-			if len(seg.Data) > 1 {
-				seg.Data = seg.Data[1:]
+			if m.segmentSize(seg) > 1 {
+				seg.Data = seg.Data[unit:]
 			} else {
 				m.removeSegment(index)
 			}
-		} else if offset == uint32(len(seg.Data)-1) {
-			if len(seg.Data) > 1 {
-				seg.Data = seg.Data[:offset]
+		} else if offset == m.segmentSize(seg)-1 {
+			if m.segmentSize(seg) > 1 {
+				seg.Data = seg.Data[:offset*unit]
 			} else {
 				m.removeSegment(index)
 			}
 		} else {
-			newSeg := DataSegment{Address: seg.Address + offset + 1, Data: seg.Data[offset+1:]}
-			seg.Data = seg.Data[:offset]
+			newSeg := DataSegment{Address: seg.Address + offset + 1, Data: seg.Data[(offset+1)*unit:]}
+			seg.Data = seg.Data[:offset*unit]
 			m.dataSegments = append(m.dataSegments, &newSeg)
 		}
 	}
 	sort.Sort(sortByAddress(m.dataSegments))
+	m.setProvenance(adr, size, nil)
+}
+
+// Method to remove runs of fill bytes (at least minSize long, optionally page aligned) from memory
+func (m *Memory) StripPadding(fill byte, minSize uint32, align uint32) {
+	type run struct{ adr, size uint32 }
+	runs := []run{}
+	for _, s := range m.dataSegments {
+		start := 0
+		for start < len(s.Data) {
+			if s.Data[start] != fill {
+				start++
+				continue
+			}
+			end := start
+			for end < len(s.Data) && s.Data[end] == fill {
+				end++
+			}
+			unit := uint64(m.addressUnit)
+			adr := uint64(s.Address) + (uint64(start)+unit-1)/unit
+			adrEnd := uint64(s.Address) + uint64(end)/unit
+			if align > 1 {
+				adr = (adr + uint64(align) - 1) / uint64(align) * uint64(align)
+				adrEnd = adrEnd / uint64(align) * uint64(align)
+			}
+			if adrEnd > adr && adrEnd-adr >= uint64(minSize) {
+				runs = append(runs, run{adr: uint32(adr), size: uint32(adrEnd - adr)})
+			}
+			start = end
+		}
+	}
+	for _, r := range runs {
+		m.RemoveBinary(r.adr, r.size)
+	}
 }
 
 func (m *Memory) parseIntelHexRecord(bytes []byte) error {
@@ -257,14 +453,15 @@ func (m *Memory) parseIntelHexLine(line string) error {
 	return m.parseIntelHexRecord(bytes)
 }
 
-// Method to parsing IntelHex data and add into memory
-func (m *Memory) ParseIntelHex(reader io.Reader) error {
+func (m *Memory) parseLines(reader io.Reader, parseLine func(line string) error) error {
 	scanner := bufio.NewScanner(reader)
 	m.Clear()
+	m.parsingFlag = true
+	defer func() { m.parsingFlag = false }()
 	for scanner.Scan() {
 		m.lineNum++
-		line := scanner.Text()
-		err := m.parseIntelHexLine(line)
+		line := strings.TrimSpace(scanner.Text())
+		err := parseLine(line)
 		if err != nil {
 			return err
 		}
@@ -279,10 +476,16 @@ func (m *Memory) ParseIntelHex(reader io.Reader) error {
 	return nil
 }
 
+// Method to parsing IntelHex data and add into memory
+func (m *Memory) ParseIntelHex(reader io.Reader) error {
+	return m.parseLines(reader, m.parseIntelHexLine)
+}
+
 func (m *Memory) dumpDataSegment(writer io.Writer, s *DataSegment, lineLength byte) error {
+	unit := m.addressUnit
 	lineAdr := s.Address
 	lineData := []byte{}
-	for byteAdr := s.Address; byteAdr < s.Address+uint32(len(s.Data)); byteAdr++ {
+	for byteAdr := s.Address; byteAdr < s.Address+m.segmentSize(s); byteAdr++ {
 		if ((byteAdr & 0xFFFF0000) != m.extendedAddress) || (m.firstAddressFlag == false) {
 			m.firstAddressFlag = true
 			if len(lineData) != 0 {
@@ -294,13 +497,14 @@ func (m *Memory) dumpDataSegment(writer io.Writer, s *DataSegment, lineLength by
 			m.extendedAddress = (byteAdr & 0xFFFF0000)
 			writeExtendedAddressLine(writer, m.extendedAddress)
 		}
-		if len(lineData) >= int(lineLength) {
+		if len(lineData) != 0 && len(lineData)+int(unit) > int(lineLength) {
 			err := writeDataLine(writer, &lineAdr, byteAdr, &lineData)
 			if err != nil {
 				return err
 			}
 		}
-		lineData = append(lineData, s.Data[byteAdr-s.Address])
+		offset := (byteAdr - s.Address) * unit
+		lineData = append(lineData, s.Data[offset:offset+unit]...)
 	}
 
 	if len(lineData) != 0 {
@@ -332,14 +536,16 @@ func (m *Memory) DumpIntelHex(writer io.Writer, lineLength byte) error {
 
 // Method to load binary data previously loaded into memory
 func (m *Memory) ToBinary(address uint32, size uint32, padding byte) []byte {
-	data := make([]byte, size)
+	unit := m.addressUnit
+	data := make([]byte, size*unit)
 
 	i := uint32(0)
 	for i < size {
 		ok := false
 		for _, s := range m.dataSegments {
-			if (address >= s.Address) && (address < s.Address+uint32(len(s.Data))) {
-				data[i] = s.Data[address-s.Address]
+			if (address >= s.Address) && (address < s.Address+m.segmentSize(s)) {
+				offset := (address - s.Address) * unit
+				copy(data[i*unit:(i+1)*unit], s.Data[offset:offset+unit])
 				i++
 				address++
 				ok = true
@@ -347,7 +553,9 @@ func (m *Memory) ToBinary(address uint32, size uint32, padding byte) []byte {
 			}
 		}
 		if ok == false {
-			data[i] = padding
+			for j := i * unit; j < (i+1)*unit; j++ {
+				data[j] = padding
+			}
 			i++
 			address++
 		}
@@ -722,3 +722,86 @@ func TestRemoveBinary(t *testing.T) {
 		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
 	}
 }
+
+func TestStripPadding(t *testing.T) {
+	m := NewMemory()
+	d := []byte{1, 2, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 3, 0xFF, 0xFF, 4}
+	m.AddBinary(0x00, d)
+
+	m.StripPadding(0xFF, 4, 0)
+
+	data := m.ToBinary(0, 16, 0)
+	org := []byte{1, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0xFF, 0xFF, 4}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+	if len(m.GetDataSegments()) != 2 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+
+	m.Clear()
+	m.AddBinary(0x00, d)
+
+	m.StripPadding(0xFF, 4, 4)
+
+	data = m.ToBinary(0, 16, 0)
+	org = []byte{1, 2, 0xFF, 0xFF, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0xFF, 0xFF, 4}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+	if len(m.GetDataSegments()) != 2 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+
+	m.Clear()
+	m.AddBinary(0x00, d)
+
+	m.StripPadding(0xFF, 16, 0)
+
+	if len(m.GetDataSegments()) != 1 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+}
+
+func TestCloneAndEqual(t *testing.T) {
+	m := NewMemory()
+	m.SetStartAddress(0x1234)
+	m.AddBinary(0x00, []byte{0, 1, 2, 3})
+	m.AddBinary(0x08, []byte{8, 9, 10, 11})
+
+	c := m.Clone()
+	if m.Equal(c) != true {
+		t.Error("clone not equal to memory")
+	}
+	c.SetBinary(0x01, []byte{0xAA})
+	if m.Equal(c) != false {
+		t.Error("modified clone equal to memory")
+	}
+	if data := m.ToBinary(0x01, 1, 0); data[0] != 1 {
+		t.Errorf("memory modified by clone: %v", data)
+	}
+	if m.EqualRange(c, 0x02, 10) != true {
+		t.Error("memory range not equal")
+	}
+	if m.EqualRange(c, 0x00, 2) != false {
+		t.Error("modified memory range equal")
+	}
+
+	c = m.Clone()
+	c.RemoveBinary(0x0B, 1)
+	if m.EqualRange(c, 0x00, 11) != true || m.EqualRange(c, 0x00, 12) != false {
+		t.Error("incorrect range compare with gaps")
+	}
+
+	c = m.Clone()
+	c.SetStartAddress(0x4321)
+	if m.Equal(c) != false {
+		t.Error("memory with other start address equal")
+	}
+
+	segs := m.GetDataSegments()
+	segs[0].Data[0] = 0xFF
+	if data := m.ToBinary(0x00, 1, 0); data[0] != 0 {
+		t.Errorf("memory modified by data segments copy: %v", data)
+	}
+}
@@ -0,0 +1,195 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// Type of source of firmware image header field value
+type HeaderValueSource int
+
+// Constants definitions of header field value sources
+const (
+	HeaderConstant     HeaderValueSource = 0 // Constant value of field (magic, version)
+	HeaderImageSize    HeaderValueSource = 1 // Size of image range in bytes
+	HeaderImageAddress HeaderValueSource = 2 // Starting address of image range (load address)
+	HeaderStartAddress HeaderValueSource = 3 // Start address of memory (entry point)
+	HeaderImageCRC32   HeaderValueSource = 4 // CRC-32 (IEEE) of image range (gaps filled with padding)
+)
+
+// Structure with description of firmware image header field
+type HeaderField struct {
+	Name      string            // Name of field (key of values read from header)
+	Offset    uint32            // Offset of field from header address in bytes
+	Size      uint32            // Size of field in bytes (1, 2, 4 or 8)
+	ByteOrder binary.ByteOrder  // Byte order of field (nil means little endian)
+	Source    HeaderValueSource // Source of field value
+	Value     uint64            // Value of field with constant source
+}
+
+// Structure with description of firmware image header layout
+type HeaderLayout struct {
+	Size    uint32        // Size of header in bytes
+	Fields  []HeaderField // Fields of header
+	Padding byte          // Value of byte used to fill header space between fields and image gaps
+}
+
+func (layout *HeaderLayout) check() error {
+	names := map[string]bool{}
+	for i, f := range layout.Fields {
+		switch f.Size {
+		case 1, 2, 4, 8:
+		default:
+			return fmt.Errorf("incorrect size of header field %s", f.Name)
+		}
+		if uint64(f.Offset)+uint64(f.Size) > uint64(layout.Size) {
+			return fmt.Errorf("header field %s outside of header", f.Name)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("duplicated header field %s", f.Name)
+		}
+		names[f.Name] = true
+		for _, o := range layout.Fields[:i] {
+			if f.Offset < o.Offset+o.Size && o.Offset < f.Offset+f.Size {
+				return fmt.Errorf("header field %s overlaps header field %s", f.Name, o.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *HeaderField) order() binary.ByteOrder {
+	if f.ByteOrder == nil {
+		return binary.LittleEndian
+	}
+	return f.ByteOrder
+}
+
+func (f *HeaderField) encode(data []byte, value uint64) error {
+	if f.Size < 8 && value>>(f.Size*8) != 0 {
+		return fmt.Errorf("value of header field %s does not fit in %d bytes", f.Name, f.Size)
+	}
+	data = data[f.Offset : f.Offset+f.Size]
+	switch f.Size {
+	case 2:
+		f.order().PutUint16(data, uint16(value))
+	case 4:
+		f.order().PutUint32(data, uint32(value))
+	case 8:
+		f.order().PutUint64(data, value)
+	default:
+		data[0] = byte(value)
+	}
+	return nil
+}
+
+func (f *HeaderField) decode(data []byte) uint64 {
+	data = data[f.Offset : f.Offset+f.Size]
+	switch f.Size {
+	case 2:
+		return uint64(f.order().Uint16(data))
+	case 4:
+		return uint64(f.order().Uint32(data))
+	case 8:
+		return f.order().Uint64(data)
+	}
+	return uint64(data[0])
+}
+
+func (m *Memory) headerFieldValue(f *HeaderField, imageAddress uint32, imageSize uint32, padding byte) (uint64, error) {
+	switch f.Source {
+	case HeaderConstant:
+		return f.Value, nil
+	case HeaderImageSize:
+		return uint64(imageSize), nil
+	case HeaderImageAddress:
+		return uint64(imageAddress), nil
+	case HeaderStartAddress:
+		adr, ok := m.GetStartAddress()
+		if ok == false {
+			return 0, errors.New("no start address")
+		}
+		return uint64(adr), nil
+	case HeaderImageCRC32:
+		return uint64(crc32.ChecksumIEEE(m.ToBinary(imageAddress, imageSize, padding))), nil
+	}
+	return 0, fmt.Errorf("incorrect value source of header field %s", f.Name)
+}
+
+// Method to build firmware image header of image range and write it into memory at header address
+func (m *Memory) WriteHeader(address uint32, layout HeaderLayout, imageAddress uint32, imageSize uint32) error {
+	if m.addressUnit != 1 {
+		return errors.New("header of memory with multi-byte address unit")
+	}
+	err := layout.check()
+	if err != nil {
+		return err
+	}
+	if uint64(address)+uint64(layout.Size) > 0x100000000 {
+		return errors.New("header above 32-bit address space")
+	}
+	header := make([]byte, layout.Size)
+	for i := range header {
+		header[i] = layout.Padding
+	}
+	for i := range layout.Fields {
+		f := &layout.Fields[i]
+		value, err := m.headerFieldValue(f, imageAddress, imageSize, layout.Padding)
+		if err != nil {
+			return err
+		}
+		err = f.encode(header, value)
+		if err != nil {
+			return err
+		}
+	}
+	return m.SetBinary(address, header)
+}
+
+// Method to read values of firmware image header fields from memory at header address (constant fields are checked)
+func (m *Memory) ReadHeader(address uint32, layout HeaderLayout) (map[string]uint64, error) {
+	if m.addressUnit != 1 {
+		return nil, errors.New("header of memory with multi-byte address unit")
+	}
+	err := layout.check()
+	if err != nil {
+		return nil, err
+	}
+	if gaps := m.Gaps(address, layout.Size); len(gaps) != 0 {
+		return nil, fmt.Errorf("no header data at address 0x%08X", gaps[0].Address)
+	}
+	header := m.ToBinary(address, layout.Size, layout.Padding)
+	values := map[string]uint64{}
+	for i := range layout.Fields {
+		f := &layout.Fields[i]
+		values[f.Name] = f.decode(header)
+		if f.Source == HeaderConstant && values[f.Name] != f.Value {
+			return nil, fmt.Errorf("incorrect value of header field %s (0x%X != 0x%X)", f.Name, values[f.Name], f.Value)
+		}
+	}
+	return values, nil
+}
+
+// Method to verify all firmware image header fields in memory against image range
+func (m *Memory) VerifyHeader(address uint32, layout HeaderLayout, imageAddress uint32, imageSize uint32) error {
+	values, err := m.ReadHeader(address, layout)
+	if err != nil {
+		return err
+	}
+	for i := range layout.Fields {
+		f := &layout.Fields[i]
+		value, err := m.headerFieldValue(f, imageAddress, imageSize, layout.Padding)
+		if err != nil {
+			return err
+		}
+		if f.Size < 8 {
+			value &= 1<<(f.Size*8) - 1
+		}
+		if values[f.Name] != value {
+			return fmt.Errorf("incorrect value of header field %s (0x%X != 0x%X)", f.Name, values[f.Name], value)
+		}
+	}
+	return nil
+}
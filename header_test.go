@@ -0,0 +1,97 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"reflect"
+	"testing"
+)
+
+func testHeaderLayout() HeaderLayout {
+	return HeaderLayout{
+		Size:    0x20,
+		Padding: 0xFF,
+		Fields: []HeaderField{
+			{Name: "magic", Offset: 0x00, Size: 4, Source: HeaderConstant, Value: 0x48444852},
+			{Name: "version", Offset: 0x04, Size: 2, ByteOrder: binary.BigEndian, Source: HeaderConstant, Value: 0x0102},
+			{Name: "size", Offset: 0x08, Size: 4, Source: HeaderImageSize},
+			{Name: "load", Offset: 0x0C, Size: 4, Source: HeaderImageAddress},
+			{Name: "entry", Offset: 0x10, Size: 4, Source: HeaderStartAddress},
+			{Name: "crc", Offset: 0x14, Size: 4, Source: HeaderImageCRC32},
+		},
+	}
+}
+
+func TestWriteHeader(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x1020, []byte{1, 2, 3, 4})
+	m.AddBinary(0x1026, []byte{5, 6})
+	m.SetStartAddress(0x1021)
+	layout := testHeaderLayout()
+
+	err := m.WriteHeader(0x1000, layout, 0x1020, 8)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	header := m.ToBinary(0x1000, 0x20, 0x00)
+	org := []byte{
+		0x52, 0x48, 0x44, 0x48, 0x01, 0x02, 0xFF, 0xFF,
+		0x08, 0x00, 0x00, 0x00, 0x20, 0x10, 0x00, 0x00,
+		0x21, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+	}
+	binary.LittleEndian.PutUint32(org[0x14:], crc32.ChecksumIEEE([]byte{1, 2, 3, 4, 0xFF, 0xFF, 5, 6}))
+	if reflect.DeepEqual(header, org) == false {
+		t.Errorf("incorrect header: %v", header)
+	}
+
+	values, err := m.ReadHeader(0x1000, layout)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if values["size"] != 8 || values["load"] != 0x1020 || values["entry"] != 0x1021 || values["version"] != 0x0102 {
+		t.Errorf("incorrect header values: %v", values)
+	}
+	if err = m.VerifyHeader(0x1000, layout, 0x1020, 8); err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+
+	m.SetBinary(0x1021, []byte{0})
+	if err = m.VerifyHeader(0x1000, layout, 0x1020, 8); err == nil {
+		t.Error("no crc error")
+	}
+	m.SetBinary(0x1000, []byte{0})
+	if _, err = m.ReadHeader(0x1000, layout); err == nil {
+		t.Error("no magic error")
+	}
+	if _, err = m.ReadHeader(0x2000, layout); err == nil {
+		t.Error("no header data error")
+	}
+}
+
+func TestHeaderLayoutErrors(t *testing.T) {
+	m := NewMemory()
+	layout := HeaderLayout{Size: 4, Fields: []HeaderField{{Name: "a", Offset: 2, Size: 4}}}
+	if err := m.WriteHeader(0, layout, 0, 0); err == nil {
+		t.Error("no field outside header error")
+	}
+	layout = HeaderLayout{Size: 4, Fields: []HeaderField{{Name: "a", Size: 3}}}
+	if err := m.WriteHeader(0, layout, 0, 0); err == nil {
+		t.Error("no field size error")
+	}
+	layout = HeaderLayout{Size: 4, Fields: []HeaderField{{Name: "a", Size: 1, Source: HeaderImageSize}}}
+	if err := m.WriteHeader(0, layout, 0, 0x100); err == nil {
+		t.Error("no value overflow error")
+	}
+	layout = HeaderLayout{Size: 4, Fields: []HeaderField{{Name: "a", Size: 4, Source: HeaderStartAddress}}}
+	if err := m.WriteHeader(0, layout, 0, 0); err == nil {
+		t.Error("no start address error")
+	}
+	layout = HeaderLayout{Size: 8, Fields: []HeaderField{{Name: "a", Offset: 0, Size: 4}, {Name: "b", Offset: 3, Size: 2}}}
+	if err := m.WriteHeader(0, layout, 0, 0); err == nil {
+		t.Error("no field overlap error")
+	}
+	if _, err := m.ReadHeader(0, layout); err == nil {
+		t.Error("no field overlap error")
+	}
+}
@@ -112,3 +112,61 @@ func writeEofLine(writer io.Writer) error {
 	_, err := fmt.Fprintf(writer, ":%s\n", s)
 	return err
 }
+
+func trimPadding(data []byte, padding byte, unit uint32) []byte {
+	size := len(data)
+	for size > 0 && data[size-1] == padding {
+		size--
+	}
+	size = (size + int(unit) - 1) / int(unit) * int(unit)
+	if size > len(data) {
+		size = len(data)
+	}
+	return data[:size]
+}
+
+func writePadding(writer io.Writer, size uint64, padding byte) error {
+	if size == 0 {
+		return nil
+	}
+	chunk := make([]byte, 256)
+	if size < uint64(len(chunk)) {
+		chunk = chunk[:size]
+	}
+	for i := range chunk {
+		chunk[i] = padding
+	}
+	for size > 0 {
+		n := uint64(len(chunk))
+		if size < n {
+			n = size
+		}
+		_, err := writer.Write(chunk[:n])
+		if err != nil {
+			return err
+		}
+		size -= n
+	}
+	return nil
+}
+
+func swapWordBytes(data []byte) []byte {
+	swapped := make([]byte, len(data))
+	for i := 0; i+1 < len(data); i += 2 {
+		swapped[i], swapped[i+1] = data[i+1], data[i]
+	}
+	return swapped
+}
+
+func makeWordDataLine(wordAdr uint16, data []byte) []byte {
+	line := makeDataLine(wordAdr, _DATA_RECORD, swapWordBytes(data))
+	line[0] = byte(len(data) / 2)
+	line[len(line)-1] = calcSum(line[:len(line)-1])
+	return line
+}
+
+func writeRecordLine(writer io.Writer, line []byte) error {
+	s := strings.ToUpper(hex.EncodeToString(line))
+	_, err := fmt.Fprintf(writer, ":%s\n", s)
+	return err
+}
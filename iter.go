@@ -0,0 +1,76 @@
+package gohex
+
+import (
+	"iter"
+)
+
+// Method to iterate over data segments (data bytes are shared with memory, memory must not be modified during iteration)
+func (m *Memory) Segments() iter.Seq[DataSegment] {
+	return func(yield func(DataSegment) bool) {
+		for _, s := range m.dataSegments {
+			if yield(*s) == false {
+				return
+			}
+		}
+	}
+}
+
+// Method to iterate over parts of data segments intersecting address range (data bytes are shared with memory)
+func (m *Memory) SegmentsInRange(address uint32, size uint32) iter.Seq[DataSegment] {
+	return func(yield func(DataSegment) bool) {
+		for _, s := range m.segmentsInRange(address, size) {
+			if yield(s) == false {
+				return
+			}
+		}
+	}
+}
+
+// Method to iterate over contiguous data chunks of at most chunkSize units not crossing chunkSize aligned boundaries
+func (m *Memory) Chunks(address uint32, size uint32, chunkSize uint32) iter.Seq[DataSegment] {
+	return func(yield func(DataSegment) bool) {
+		if chunkSize == 0 {
+			return
+		}
+		unit := uint64(m.addressUnit)
+		for _, s := range m.segmentsInRange(address, size) {
+			current := uint64(s.Address)
+			end := current + uint64(len(s.Data))/unit
+			for current < end {
+				next := (current/uint64(chunkSize) + 1) * uint64(chunkSize)
+				if next > end {
+					next = end
+				}
+				offset := (current - uint64(s.Address)) * unit
+				chunk := DataSegment{Address: uint32(current), Data: s.Data[offset : offset+(next-current)*unit]}
+				if yield(chunk) == false {
+					return
+				}
+				current = next
+			}
+		}
+	}
+}
+
+// Method to iterate over populated (true) and gap (false) ranges in address range
+func (m *Memory) Ranges(address uint32, size uint32) iter.Seq2[AddressRange, bool] {
+	return func(yield func(AddressRange, bool) bool) {
+		current := uint64(address)
+		for _, s := range m.segmentsInRange(address, size) {
+			if uint64(s.Address) > current {
+				if yield(AddressRange{Address: uint32(current), Size: uint32(uint64(s.Address) - current)}, false) == false {
+					return
+				}
+			}
+			segSize := uint32(len(s.Data)) / m.addressUnit
+			if yield(AddressRange{Address: s.Address, Size: segSize}, true) == false {
+				return
+			}
+			current = uint64(s.Address) + uint64(segSize)
+		}
+		end := uint64(address) + uint64(size)
+		if current < end {
+			yield(AddressRange{Address: uint32(current), Size: uint32(end - current)}, false)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package gohex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSegmentsIterators(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x00, []byte{0, 1, 2, 3})
+	m.AddBinary(0x08, []byte{8, 9, 10, 11})
+	m.AddBinary(0x10, []byte{16, 17})
+
+	segs := []DataSegment{}
+	for s := range m.Segments() {
+		segs = append(segs, s)
+	}
+	if reflect.DeepEqual(segs, m.GetDataSegments()) == false {
+		t.Errorf("incorrect segments: %v", segs)
+	}
+
+	segs = []DataSegment{}
+	for s := range m.SegmentsInRange(0x02, 0x08) {
+		segs = append(segs, s)
+	}
+	org := []DataSegment{{Address: 0x02, Data: []byte{2, 3}}, {Address: 0x08, Data: []byte{8, 9}}}
+	if reflect.DeepEqual(segs, org) == false {
+		t.Errorf("incorrect segments: %v", segs)
+	}
+
+	segs = []DataSegment{}
+	for s := range m.SegmentsInRange(0, 0x20) {
+		segs = append(segs, s)
+		break
+	}
+	if len(segs) != 1 {
+		t.Errorf("incorrect number of data segments: %v", len(segs))
+	}
+
+	for s := range m.Chunks(0, 0x20, 4) {
+		s.Data[0] = 0xEE
+		break
+	}
+	if data := m.ToBinary(0, 1, 0); data[0] != 0xEE {
+		t.Errorf("iterated data not shared with memory: %v", data)
+	}
+}
+
+func TestChunks(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x01, []byte{1, 2, 3, 4, 5, 6})
+	m.AddBinary(0x09, []byte{9})
+
+	segs := []DataSegment{}
+	for s := range m.Chunks(0, 0x10, 4) {
+		segs = append(segs, s)
+	}
+	org := []DataSegment{
+		{Address: 0x01, Data: []byte{1, 2, 3}},
+		{Address: 0x04, Data: []byte{4, 5, 6}},
+		{Address: 0x09, Data: []byte{9}},
+	}
+	if reflect.DeepEqual(segs, org) == false {
+		t.Errorf("incorrect chunks: %v", segs)
+	}
+}
+
+func TestRanges(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x02, []byte{2, 3})
+	m.AddBinary(0x08, []byte{8, 9, 10, 11})
+
+	type r struct {
+		AddressRange
+		Used bool
+	}
+	ranges := []r{}
+	for a, used := range m.Ranges(0, 0x0A) {
+		ranges = append(ranges, r{a, used})
+	}
+	org := []r{
+		{AddressRange{Address: 0x00, Size: 2}, false},
+		{AddressRange{Address: 0x02, Size: 2}, true},
+		{AddressRange{Address: 0x04, Size: 4}, false},
+		{AddressRange{Address: 0x08, Size: 2}, true},
+	}
+	if reflect.DeepEqual(ranges, org) == false {
+		t.Errorf("incorrect ranges: %v", ranges)
+	}
+}
@@ -0,0 +1,138 @@
+package gohex
+
+import (
+	"errors"
+	"sort"
+)
+
+// Helper type for building memory from bytes added in ascending address order
+type runBuilder struct {
+	m    *Memory
+	adr  uint32
+	data []byte
+	err  error
+}
+
+func (b *runBuilder) add(adr uint32, v byte) {
+	if len(b.data) != 0 && b.adr+uint32(len(b.data)) != adr {
+		b.flush()
+	}
+	if len(b.data) == 0 {
+		b.adr = adr
+	}
+	b.data = append(b.data, v)
+}
+
+func (b *runBuilder) flush() {
+	if len(b.data) == 0 {
+		return
+	}
+	err := b.m.AddBinary(b.adr, b.data)
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+	b.data = nil
+}
+
+// Helper type for reading bytes from memory in ascending address order
+type segmentCursor struct {
+	segs  []*DataSegment
+	index int
+}
+
+func (c *segmentCursor) get(adr uint64) (byte, bool) {
+	for c.index < len(c.segs) && uint64(c.segs[c.index].Address)+uint64(len(c.segs[c.index].Data)) <= adr {
+		c.index++
+	}
+	if c.index < len(c.segs) && uint64(c.segs[c.index].Address) <= adr {
+		s := c.segs[c.index]
+		return s.Data[adr-uint64(s.Address)], true
+	}
+	return 0, false
+}
+
+// Method to split memory into byte lanes (every lanes-th group of laneWidth bytes goes to the same lane)
+func (m *Memory) SplitLanes(lanes uint32, laneWidth uint32) ([]*Memory, error) {
+	if lanes == 0 || laneWidth == 0 {
+		return nil, errors.New("incorrect number of lanes or lane width")
+	}
+	if m.addressUnit != 1 {
+		return nil, errors.New("lanes splitting of memory with multi-byte address unit")
+	}
+	group := uint64(lanes) * uint64(laneWidth)
+	builders := make([]*runBuilder, lanes)
+	for i := range builders {
+		builders[i] = &runBuilder{m: NewMemory()}
+	}
+	for _, s := range m.dataSegments {
+		for i, b := range s.Data {
+			adr := uint64(s.Address) + uint64(i)
+			within := adr % group
+			lane := within / uint64(laneWidth)
+			builders[lane].add(uint32(adr/group*uint64(laneWidth)+within%uint64(laneWidth)), b)
+		}
+	}
+	mems := make([]*Memory, lanes)
+	for i, b := range builders {
+		b.flush()
+		if b.err != nil {
+			return nil, b.err
+		}
+		mems[i] = b.m
+	}
+	return mems, nil
+}
+
+// Function to interleave byte lane memories back into one memory
+func InterleaveLanes(lanes []*Memory, laneWidth uint32) (*Memory, error) {
+	if len(lanes) == 0 || laneWidth == 0 {
+		return nil, errors.New("incorrect number of lanes or lane width")
+	}
+	w := uint64(laneWidth)
+	group := uint64(len(lanes)) * w
+
+	intervals := []AddressRange{}
+	cursors := make([]*segmentCursor, len(lanes))
+	for i, l := range lanes {
+		if l.addressUnit != 1 {
+			return nil, errors.New("lanes interleaving of memory with multi-byte address unit")
+		}
+		cursors[i] = &segmentCursor{segs: l.dataSegments}
+		for _, s := range l.dataSegments {
+			first := uint64(s.Address) / w
+			last := (uint64(s.Address) + uint64(len(s.Data)) - 1) / w
+			if (last+1)*group > 0x100000000 {
+				return nil, errors.New("interleaved data above 32-bit address space")
+			}
+			intervals = append(intervals, AddressRange{Address: uint32(first), Size: uint32(last - first + 1)})
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Address < intervals[j].Address })
+
+	b := &runBuilder{m: NewMemory()}
+	next := uint64(0)
+	for _, interval := range intervals {
+		start := uint64(interval.Address)
+		if start < next {
+			start = next
+		}
+		end := uint64(interval.Address) + uint64(interval.Size)
+		for g := start; g < end; g++ {
+			for i, c := range cursors {
+				for off := uint64(0); off < w; off++ {
+					if v, ok := c.get(g*w + off); ok {
+						b.add(uint32(g*group+uint64(i)*w+off), v)
+					}
+				}
+			}
+		}
+		if end > next {
+			next = end
+		}
+	}
+	b.flush()
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.m, nil
+}
@@ -0,0 +1,67 @@
+package gohex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitLanes(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x1000, []byte{0, 1, 2, 3, 4, 5, 6, 7, 8})
+
+	lanes, err := m.SplitLanes(2, 1)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := lanes[0].GetDataSegments()[0]
+	p := DataSegment{Address: 0x800, Data: []byte{0, 2, 4, 6, 8}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	seg = lanes[1].GetDataSegments()[0]
+	p = DataSegment{Address: 0x800, Data: []byte{1, 3, 5, 7}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	lanes, err = m.SplitLanes(2, 2)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg = lanes[0].GetDataSegments()[0]
+	p = DataSegment{Address: 0x800, Data: []byte{0, 1, 4, 5, 8}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	seg = lanes[1].GetDataSegments()[0]
+	p = DataSegment{Address: 0x800, Data: []byte{2, 3, 6, 7}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	if _, err = m.SplitLanes(0, 1); err == nil {
+		t.Error("no lanes number error")
+	}
+}
+
+func TestInterleaveLanes(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x1001, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	m.AddBinary(0x80000000, []byte{1, 2, 3})
+
+	for _, width := range []uint32{1, 2} {
+		for _, n := range []uint32{1, 2, 4} {
+			lanes, err := m.SplitLanes(n, width)
+			if err != nil {
+				t.Error("unexpected error: ", err.Error())
+			}
+			r, err := InterleaveLanes(lanes, width)
+			if err != nil {
+				t.Error("unexpected error: ", err.Error())
+			}
+			if m.Equal(r) == false {
+				t.Errorf("incorrect interleaved memory (%v lanes, width %v): %v", n, width, r.GetDataSegments())
+			}
+		}
+	}
+}
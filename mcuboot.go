@@ -0,0 +1,281 @@
+package gohex
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Constants definitions of MCUboot image format
+const (
+	_MCUBOOT_IMAGE_MAGIC        uint32 = 0x96F3B83D // Magic number of image header
+	_MCUBOOT_TLV_INFO_MAGIC     uint16 = 0x6907     // Magic number of unprotected TLV area
+	_MCUBOOT_TLV_PROT_MAGIC     uint16 = 0x6908     // Magic number of protected TLV area
+	_MCUBOOT_TLV_KEYHASH        uint16 = 0x01       // TLV with SHA-256 of public key
+	_MCUBOOT_TLV_SHA256         uint16 = 0x10       // TLV with SHA-256 of image
+	_MCUBOOT_TLV_ECDSA_SIG      uint16 = 0x22       // TLV with ECDSA-P256 signature of image hash
+	_MCUBOOT_TLV_ED25519        uint16 = 0x24       // TLV with ed25519 signature of image hash
+	_MCUBOOT_HEADER_SIZE        uint32 = 0x20       // Size of image header structure
+	_MCUBOOT_DEFAULT_HDR        uint32 = 0x200      // Default size of image header area
+	_MCUBOOT_TRAILER_MAGIC_SIZE uint32 = 16         // Size of boot magic at the end of slot
+)
+
+var mcubootTrailerMagic = []byte{
+	0x77, 0xC2, 0x95, 0xF3, 0x60, 0xD2, 0xEF, 0x7F,
+	0x35, 0x52, 0x50, 0x0F, 0x2C, 0xB6, 0x79, 0x80,
+}
+
+// Structure with MCUboot image version fields
+type MCUbootVersion struct {
+	Major    uint8  // Major version number
+	Minor    uint8  // Minor version number
+	Revision uint16 // Revision number
+	Build    uint32 // Build number
+}
+
+// Structure with MCUboot image header fields
+type MCUbootHeader struct {
+	LoadAddress      uint32         // Load address of image (used by RAM load mode)
+	HeaderSize       uint16         // Size of image header area in bytes
+	ProtectedTLVSize uint16         // Size of protected TLV area in bytes
+	ImageSize        uint32         // Size of image (without header) in bytes
+	Flags            uint32         // Image flags
+	Version          MCUbootVersion // Image version
+}
+
+// Structure with settings of MCUboot image generation
+type MCUbootOptions struct {
+	HeaderSize  uint32         // Size of image header area in bytes (0 means 0x200)
+	LoadAddress uint32         // Load address of image stored in header
+	Flags       uint32         // Image flags stored in header
+	Version     MCUbootVersion // Image version stored in header
+	SlotSize    uint32         // Size of slot padded with boot magic at the end (0 means no padding)
+	Padding     byte           // Value of byte used to fill image gaps and slot padding
+	Signer      crypto.Signer  // Signing key (nil, ed25519 or ECDSA-P256 private key)
+}
+
+func mcubootSignatureType(key crypto.PublicKey) (uint16, error) {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return _MCUBOOT_TLV_ED25519, nil
+	case *ecdsa.PublicKey:
+		if k.Curve == elliptic.P256() {
+			return _MCUBOOT_TLV_ECDSA_SIG, nil
+		}
+	}
+	return 0, errors.New("unsupported key type")
+}
+
+func mcubootKeyHash(key crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(der)
+	return hash[:], nil
+}
+
+func appendTLV(tlvs []byte, tlvType uint16, value []byte) []byte {
+	tlvs = binary.LittleEndian.AppendUint16(tlvs, tlvType)
+	tlvs = binary.LittleEndian.AppendUint16(tlvs, uint16(len(value)))
+	return append(tlvs, value...)
+}
+
+// Method to generating MCUboot image of memory range placed into new memory at slot address
+func (m *Memory) MCUbootImage(address uint32, size uint32, slotAddress uint32, opts MCUbootOptions) (*Memory, error) {
+	if m.addressUnit != 1 {
+		return nil, errors.New("MCUboot image of memory with multi-byte address unit")
+	}
+	if opts.HeaderSize == 0 {
+		opts.HeaderSize = _MCUBOOT_DEFAULT_HDR
+	}
+	if opts.HeaderSize < _MCUBOOT_HEADER_SIZE || opts.HeaderSize > 0xFFFF {
+		return nil, errors.New("incorrect header size")
+	}
+
+	image := make([]byte, opts.HeaderSize, uint64(opts.HeaderSize)+uint64(size))
+	binary.LittleEndian.PutUint32(image[0:], _MCUBOOT_IMAGE_MAGIC)
+	binary.LittleEndian.PutUint32(image[4:], opts.LoadAddress)
+	binary.LittleEndian.PutUint16(image[8:], uint16(opts.HeaderSize))
+	binary.LittleEndian.PutUint32(image[12:], size)
+	binary.LittleEndian.PutUint32(image[16:], opts.Flags)
+	image[20] = opts.Version.Major
+	image[21] = opts.Version.Minor
+	binary.LittleEndian.PutUint16(image[22:], opts.Version.Revision)
+	binary.LittleEndian.PutUint32(image[24:], opts.Version.Build)
+	image = append(image, m.ToBinary(address, size, opts.Padding)...)
+	hash := sha256.Sum256(image)
+
+	tlvs := appendTLV([]byte{}, _MCUBOOT_TLV_SHA256, hash[:])
+	if opts.Signer != nil {
+		sigType, err := mcubootSignatureType(opts.Signer.Public())
+		if err != nil {
+			return nil, err
+		}
+		keyHash, err := mcubootKeyHash(opts.Signer.Public())
+		if err != nil {
+			return nil, err
+		}
+		tlvs = appendTLV(tlvs, _MCUBOOT_TLV_KEYHASH, keyHash)
+		signerOpts := crypto.Hash(0)
+		if sigType == _MCUBOOT_TLV_ECDSA_SIG {
+			signerOpts = crypto.SHA256
+		}
+		sig, err := opts.Signer.Sign(rand.Reader, hash[:], signerOpts)
+		if err != nil {
+			return nil, err
+		}
+		tlvs = appendTLV(tlvs, sigType, sig)
+	}
+	image = binary.LittleEndian.AppendUint16(image, _MCUBOOT_TLV_INFO_MAGIC)
+	image = binary.LittleEndian.AppendUint16(image, uint16(len(tlvs)+4))
+	image = append(image, tlvs...)
+
+	if opts.SlotSize != 0 {
+		if uint64(len(image))+uint64(_MCUBOOT_TRAILER_MAGIC_SIZE) > uint64(opts.SlotSize) {
+			return nil, errors.New("image too big for slot")
+		}
+		for uint32(len(image)) < opts.SlotSize-_MCUBOOT_TRAILER_MAGIC_SIZE {
+			image = append(image, opts.Padding)
+		}
+		image = append(image, mcubootTrailerMagic...)
+	}
+	if uint64(slotAddress)+uint64(len(image)) > 0x100000000 {
+		return nil, errors.New("image above 32-bit address space")
+	}
+	result := NewMemory()
+	err := result.AddBinary(slotAddress, image)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *Memory) readRange(address uint64, size uint64) ([]byte, error) {
+	if address+size > 0x100000000 {
+		return nil, errors.New("range above 32-bit address space")
+	}
+	if gaps := m.Gaps(uint32(address), uint32(size)); len(gaps) != 0 {
+		return nil, fmt.Errorf("no data at address 0x%08X", gaps[0].Address)
+	}
+	return m.ToBinary(uint32(address), uint32(size), 0), nil
+}
+
+func parseTLVs(data []byte, found map[uint16][]byte) error {
+	for len(data) != 0 {
+		if len(data) < 4 {
+			return errors.New("truncated TLV entry")
+		}
+		tlvType := binary.LittleEndian.Uint16(data[0:])
+		tlvLen := int(binary.LittleEndian.Uint16(data[2:]))
+		if 4+tlvLen > len(data) {
+			return errors.New("truncated TLV entry")
+		}
+		found[tlvType] = data[4 : 4+tlvLen]
+		data = data[4+tlvLen:]
+	}
+	return nil
+}
+
+// Method to verify MCUboot image at slot address (hash always, signature when public key is not nil)
+func (m *Memory) VerifyMCUbootImage(slotAddress uint32, key crypto.PublicKey) (MCUbootHeader, error) {
+	header := MCUbootHeader{}
+	if m.addressUnit != 1 {
+		return header, errors.New("MCUboot image of memory with multi-byte address unit")
+	}
+	hdr, err := m.readRange(uint64(slotAddress), uint64(_MCUBOOT_HEADER_SIZE))
+	if err != nil {
+		return header, err
+	}
+	if binary.LittleEndian.Uint32(hdr[0:]) != _MCUBOOT_IMAGE_MAGIC {
+		return header, errors.New("incorrect image header magic")
+	}
+	header.LoadAddress = binary.LittleEndian.Uint32(hdr[4:])
+	header.HeaderSize = binary.LittleEndian.Uint16(hdr[8:])
+	header.ProtectedTLVSize = binary.LittleEndian.Uint16(hdr[10:])
+	header.ImageSize = binary.LittleEndian.Uint32(hdr[12:])
+	header.Flags = binary.LittleEndian.Uint32(hdr[16:])
+	header.Version = MCUbootVersion{
+		Major:    hdr[20],
+		Minor:    hdr[21],
+		Revision: binary.LittleEndian.Uint16(hdr[22:]),
+		Build:    binary.LittleEndian.Uint32(hdr[24:]),
+	}
+	if uint32(header.HeaderSize) < _MCUBOOT_HEADER_SIZE {
+		return header, errors.New("incorrect header size")
+	}
+
+	hashedSize := uint64(header.HeaderSize) + uint64(header.ImageSize) + uint64(header.ProtectedTLVSize)
+	hashed, err := m.readRange(uint64(slotAddress), hashedSize)
+	if err != nil {
+		return header, err
+	}
+	tlvs := map[uint16][]byte{}
+	if header.ProtectedTLVSize != 0 {
+		prot := hashed[hashedSize-uint64(header.ProtectedTLVSize):]
+		if len(prot) < 4 || binary.LittleEndian.Uint16(prot) != _MCUBOOT_TLV_PROT_MAGIC || binary.LittleEndian.Uint16(prot[2:]) != header.ProtectedTLVSize {
+			return header, errors.New("incorrect protected TLV area")
+		}
+		err = parseTLVs(prot[4:], tlvs)
+		if err != nil {
+			return header, err
+		}
+	}
+	info, err := m.readRange(uint64(slotAddress)+hashedSize, 4)
+	if err != nil {
+		return header, err
+	}
+	if binary.LittleEndian.Uint16(info) != _MCUBOOT_TLV_INFO_MAGIC || binary.LittleEndian.Uint16(info[2:]) < 4 {
+		return header, errors.New("incorrect TLV area")
+	}
+	area, err := m.readRange(uint64(slotAddress)+hashedSize+4, uint64(binary.LittleEndian.Uint16(info[2:]))-4)
+	if err != nil {
+		return header, err
+	}
+	err = parseTLVs(area, tlvs)
+	if err != nil {
+		return header, err
+	}
+
+	hash := sha256.Sum256(hashed)
+	if value, ok := tlvs[_MCUBOOT_TLV_SHA256]; ok == false || bytes.Equal(value, hash[:]) == false {
+		return header, errors.New("incorrect image hash")
+	}
+	if key == nil {
+		return header, nil
+	}
+	sigType, err := mcubootSignatureType(key)
+	if err != nil {
+		return header, err
+	}
+	if value, ok := tlvs[_MCUBOOT_TLV_KEYHASH]; ok {
+		keyHash, err := mcubootKeyHash(key)
+		if err != nil {
+			return header, err
+		}
+		if bytes.Equal(value, keyHash) == false {
+			return header, errors.New("image signed with different key")
+		}
+	}
+	sig, ok := tlvs[sigType]
+	if ok == false {
+		return header, errors.New("no image signature")
+	}
+	if sigType == _MCUBOOT_TLV_ED25519 {
+		ok = ed25519.Verify(key.(ed25519.PublicKey), hash[:], sig)
+	} else {
+		ok = ecdsa.VerifyASN1(key.(*ecdsa.PublicKey), hash[:], sig)
+	}
+	if ok == false {
+		return header, errors.New("incorrect image signature")
+	}
+	return header, nil
+}
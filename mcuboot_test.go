@@ -0,0 +1,114 @@
+package gohex
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestMCUbootImage(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x8200, []byte{1, 2, 3, 4})
+	m.AddBinary(0x8206, []byte{5, 6})
+
+	opts := MCUbootOptions{
+		HeaderSize: 0x20,
+		Version:    MCUbootVersion{Major: 1, Minor: 2, Revision: 3, Build: 4},
+		Padding:    0xFF,
+	}
+	img, err := m.MCUbootImage(0x8200, 8, 0x8000, opts)
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+	org := []byte{
+		0x3D, 0xB8, 0xF3, 0x96, 0x00, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x01, 0x02, 0x03, 0x04, 0xFF, 0xFF, 0x05, 0x06,
+	}
+	hash := sha256.Sum256(org)
+	org = append(org, 0x07, 0x69, 0x28, 0x00, 0x10, 0x00, 0x20, 0x00)
+	org = append(org, hash[:]...)
+	segs := img.GetDataSegments()
+	if len(segs) != 1 || segs[0].Address != 0x8000 || reflect.DeepEqual(segs[0].Data, org) == false {
+		t.Errorf("incorrect image: %v", segs)
+	}
+
+	header, err := img.VerifyMCUbootImage(0x8000, nil)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if header.ImageSize != 8 || header.HeaderSize != 0x20 || header.Version != opts.Version {
+		t.Errorf("incorrect header: %v", header)
+	}
+	img.SetBinary(0x8021, []byte{0})
+	if _, err = img.VerifyMCUbootImage(0x8000, nil); err == nil {
+		t.Error("no hash error")
+	}
+	if _, err = img.VerifyMCUbootImage(0x8001, nil); err == nil {
+		t.Error("no magic error")
+	}
+
+	opts.SlotSize = 0x100
+	img, err = m.MCUbootImage(0x8200, 8, 0x8000, opts)
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+	data := img.ToBinary(0x80F0, 0x10, 0x00)
+	if reflect.DeepEqual(data, mcubootTrailerMagic) == false {
+		t.Errorf("incorrect trailer magic: %v", data)
+	}
+	if v, _ := img.GetValue(0x80EF); v != 0xFF {
+		t.Errorf("incorrect slot padding: %02X", v)
+	}
+	opts.SlotSize = 0x40
+	if _, err = m.MCUbootImage(0x8200, 8, 0x8000, opts); err == nil {
+		t.Error("no slot size error")
+	}
+}
+
+func TestMCUbootImageSignature(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x0, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	edKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+	otherKey := ed25519.NewKeyFromSeed(append(make([]byte, ed25519.SeedSize-1), 1))
+
+	for _, signer := range []interface{}{edKey, ecKey} {
+		opts := MCUbootOptions{}
+		var public interface{}
+		if k, ok := signer.(ed25519.PrivateKey); ok {
+			opts.Signer = k
+			public = k.Public()
+		} else {
+			opts.Signer = signer.(*ecdsa.PrivateKey)
+			public = &signer.(*ecdsa.PrivateKey).PublicKey
+		}
+		img, err := m.MCUbootImage(0, 8, 0x1000, opts)
+		if err != nil {
+			t.Fatal("unexpected error: ", err.Error())
+		}
+		if _, err = img.VerifyMCUbootImage(0x1000, public); err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		if _, err = img.VerifyMCUbootImage(0x1000, otherKey.Public()); err == nil {
+			t.Error("no key error")
+		}
+
+		tlvSize := binary.LittleEndian.Uint16(img.ToBinary(0x1000+0x200+8+2, 2, 0))
+		sigAdr := uint32(0x1000+0x200+8) + uint32(tlvSize) - 1
+		v, _ := img.GetValue(sigAdr)
+		img.SetBinary(sigAdr, []byte{byte(v) ^ 0x01})
+		if _, err = img.VerifyMCUbootImage(0x1000, public); err == nil {
+			t.Error("no signature error")
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package gohex
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Structure with settings of FPGA memory initialization files
+type MemInitOptions struct {
+	WordWidth uint32           // Number of bytes per memory word (1, 2, 4 or 8)
+	ByteOrder binary.ByteOrder // Order of bytes in memory word (nil means big endian)
+	Padding   byte             // Value of byte used to fill gaps
+}
+
+func (opts *MemInitOptions) check() error {
+	switch opts.WordWidth {
+	case 1, 2, 4, 8:
+	default:
+		return errors.New("incorrect word width")
+	}
+	if opts.ByteOrder == nil {
+		opts.ByteOrder = binary.BigEndian
+	}
+	return nil
+}
+
+func (opts *MemInitOptions) decodeWord(data []byte) uint64 {
+	switch opts.WordWidth {
+	case 2:
+		return uint64(opts.ByteOrder.Uint16(data))
+	case 4:
+		return uint64(opts.ByteOrder.Uint32(data))
+	case 8:
+		return opts.ByteOrder.Uint64(data)
+	}
+	return uint64(data[0])
+}
+
+func (opts *MemInitOptions) encodeWord(data []byte, word uint64) {
+	switch opts.WordWidth {
+	case 2:
+		opts.ByteOrder.PutUint16(data, uint16(word))
+	case 4:
+		opts.ByteOrder.PutUint32(data, uint32(word))
+	case 8:
+		opts.ByteOrder.PutUint64(data, word)
+	default:
+		data[0] = byte(word)
+	}
+}
+
+func (m *Memory) toWords(address uint32, size uint32, opts *MemInitOptions) ([]uint64, error) {
+	err := opts.check()
+	if err != nil {
+		return nil, err
+	}
+	data := m.ToBinary(address, size, opts.Padding)
+	for len(data)%int(opts.WordWidth) != 0 {
+		data = append(data, opts.Padding)
+	}
+	words := make([]uint64, len(data)/int(opts.WordWidth))
+	for i := range words {
+		words[i] = opts.decodeWord(data[i*int(opts.WordWidth):])
+	}
+	return words, nil
+}
+
+// Method to dumping memory range as Verilog $readmemh data
+func (m *Memory) DumpReadMemH(writer io.Writer, address uint32, size uint32, opts MemInitOptions) error {
+	words, err := m.toWords(address, size, &opts)
+	if err != nil {
+		return err
+	}
+	for _, w := range words {
+		_, err = fmt.Fprintf(writer, "%0*X\n", opts.WordWidth*2, w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Method to dumping memory range as Xilinx COE data
+func (m *Memory) DumpCOE(writer io.Writer, address uint32, size uint32, opts MemInitOptions) error {
+	words, err := m.toWords(address, size, &opts)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "memory_initialization_radix=16;\nmemory_initialization_vector=\n")
+	if err != nil {
+		return err
+	}
+	for i, w := range words {
+		sep := ","
+		if i == len(words)-1 {
+			sep = ";"
+		}
+		_, err = fmt.Fprintf(writer, "%0*X%s\n", opts.WordWidth*2, w, sep)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Method to dumping memory range as Altera MIF data
+func (m *Memory) DumpMIF(writer io.Writer, address uint32, size uint32, opts MemInitOptions) error {
+	words, err := m.toWords(address, size, &opts)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "WIDTH=%d;\nDEPTH=%d;\n\nADDRESS_RADIX=HEX;\nDATA_RADIX=HEX;\n\nCONTENT BEGIN\n", opts.WordWidth*8, len(words))
+	if err != nil {
+		return err
+	}
+	for i, w := range words {
+		_, err = fmt.Fprintf(writer, "\t%X : %0*X;\n", i, opts.WordWidth*2, w)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(writer, "END;\n")
+	return err
+}
+
+func stripReadMemHComments(line string, blockComment *bool) string {
+	result := ""
+	for len(line) > 0 {
+		if *blockComment {
+			end := strings.Index(line, "*/")
+			if end < 0 {
+				return result
+			}
+			line = line[end+2:]
+			*blockComment = false
+			continue
+		}
+		lineComment := strings.Index(line, "//")
+		start := strings.Index(line, "/*")
+		if lineComment >= 0 && (start < 0 || lineComment < start) {
+			return result + line[:lineComment]
+		}
+		if start < 0 {
+			return result + line
+		}
+		result += line[:start] + " "
+		line = line[start+2:]
+		*blockComment = true
+	}
+	return result
+}
+
+// Method to parsing Verilog $readmemh data and add into memory at base address
+func (m *Memory) ParseReadMemH(reader io.Reader, address uint32, opts MemInitOptions) error {
+	m.Clear()
+	m.parsingFlag = true
+	defer func() { m.parsingFlag = false }()
+	err := opts.check()
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(reader)
+	blockComment := false
+	wordAdr := uint64(0)
+	pendingAdr := uint64(0)
+	pending := []byte{}
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		adr := uint64(address) + pendingAdr*uint64(opts.WordWidth)/uint64(m.addressUnit)
+		err := m.AddBinary(uint32(adr), pending)
+		pending = []byte{}
+		return err
+	}
+	for scanner.Scan() {
+		m.lineNum++
+		line := stripReadMemHComments(scanner.Text(), &blockComment)
+		for _, field := range strings.Fields(line) {
+			if field[0] == '@' {
+				a, err := strconv.ParseUint(field[1:], 16, 32)
+				if err != nil {
+					return newParseError(_SYNTAX_ERROR, "incorrect address field", m.lineNum)
+				}
+				err = flush()
+				if err != nil {
+					return err
+				}
+				wordAdr = a
+				continue
+			}
+			w, err := strconv.ParseUint(strings.Replace(field, "_", "", -1), 16, int(opts.WordWidth*8))
+			if err != nil {
+				return newParseError(_SYNTAX_ERROR, "incorrect data word field", m.lineNum)
+			}
+			if len(pending) == 0 {
+				pendingAdr = wordAdr
+			}
+			word := make([]byte, opts.WordWidth)
+			opts.encodeWord(word, w)
+			pending = append(pending, word...)
+			wordAdr++
+		}
+		err = flush()
+		if err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+	}
+	return flush()
+}
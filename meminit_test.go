@@ -0,0 +1,77 @@
+package gohex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDumpMemInit(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x100, []byte{1, 2, 3, 4})
+	m.AddBinary(0x106, []byte{5})
+
+	buf := bytes.Buffer{}
+	err := m.DumpReadMemH(&buf, 0x100, 7, MemInitOptions{WordWidth: 2, Padding: 0xFF})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks := "0102\n0304\nFFFF\n05FF\n"
+	if buf.String() != oks {
+		t.Errorf("wrong readmemh dump:\n%v", buf.String())
+	}
+
+	buf = bytes.Buffer{}
+	err = m.DumpCOE(&buf, 0x100, 4, MemInitOptions{WordWidth: 2, ByteOrder: binary.LittleEndian})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks = "memory_initialization_radix=16;\nmemory_initialization_vector=\n0201,\n0403;\n"
+	if buf.String() != oks {
+		t.Errorf("wrong coe dump:\n%v", buf.String())
+	}
+
+	buf = bytes.Buffer{}
+	err = m.DumpMIF(&buf, 0x100, 4, MemInitOptions{WordWidth: 4})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks = "WIDTH=32;\nDEPTH=1;\n\nADDRESS_RADIX=HEX;\nDATA_RADIX=HEX;\n\nCONTENT BEGIN\n\t0 : 01020304;\nEND;\n"
+	if buf.String() != oks {
+		t.Errorf("wrong mif dump:\n%v", buf.String())
+	}
+
+	err = m.DumpMIF(&buf, 0x100, 4, MemInitOptions{WordWidth: 3})
+	if err == nil {
+		t.Error("no word width error")
+	}
+}
+
+func TestParseReadMemH(t *testing.T) {
+	m := NewMemory()
+	input := "// boot rom\n0102 0304 /* first\nwords */ 05_06\n@10\nAABB // tail\n"
+	err := m.ParseReadMemH(strings.NewReader(input), 0x1000, MemInitOptions{WordWidth: 2, ByteOrder: binary.LittleEndian})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if len(m.GetDataSegments()) != 2 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0x1000, Data: []byte{2, 1, 4, 3, 6, 5}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	seg = m.GetDataSegments()[1]
+	p = DataSegment{Address: 0x1020, Data: []byte{0xBB, 0xAA}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	err = m.ParseReadMemH(strings.NewReader("12345\n"), 0, MemInitOptions{WordWidth: 2})
+	checkErrorType(t, err, _SYNTAX_ERROR, "no data word error")
+	err = m.ParseReadMemH(strings.NewReader("@1G\n"), 0, MemInitOptions{WordWidth: 2})
+	checkErrorType(t, err, _SYNTAX_ERROR, "no address error")
+}
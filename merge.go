@@ -0,0 +1,70 @@
+package gohex
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Method to add data of other memory (overlapping data is an error, provenance of other memory data is preserved)
+func (m *Memory) Merge(other *Memory) error {
+	if m.addressUnit != other.addressUnit {
+		return errors.New("merge of memories with different address units")
+	}
+	for _, s := range other.dataSegments {
+		if used, _ := m.Usage(s.Address, other.segmentSize(s)); used != 0 {
+			return fmt.Errorf("data segments overlap at address 0x%08X", s.Address)
+		}
+	}
+	p := m.currentProvenance("Merge")
+	for _, s := range other.copySegments() {
+		err := m.AddBinary(s.Address, s.Data)
+		if err != nil {
+			return err
+		}
+		m.setProvenance(s.Address, m.segmentSize(s), &p)
+	}
+	for _, r := range other.provenance {
+		m.setProvenance(r.Address, r.Size, &r.Provenance)
+	}
+	if m.startFlag == false && other.startFlag == true {
+		m.SetStartAddress(other.startAddress)
+	}
+	return nil
+}
+
+// Method to move data of address range to new address (provenance of moved data is preserved)
+func (m *Memory) Relocate(address uint32, size uint32, newAddress uint32) error {
+	if uint64(address)+uint64(size) > 0x100000000 || uint64(newAddress)+uint64(size) > 0x100000000 {
+		return errors.New("range above 32-bit address space")
+	}
+	segs := m.segmentsInRange(address, size)
+	for _, s := range segs {
+		adr := uint64(s.Address) - uint64(address) + uint64(newAddress)
+		for _, d := range m.segmentsInRange(uint32(adr), uint32(len(s.Data))/m.addressUnit) {
+			end := uint64(d.Address) + uint64(len(d.Data))/uint64(m.addressUnit)
+			if uint64(d.Address) < uint64(address) || end > uint64(address)+uint64(size) {
+				return fmt.Errorf("data segments overlap at address 0x%08X", d.Address)
+			}
+		}
+	}
+	moved := []DataSegment{}
+	for _, s := range segs {
+		moved = append(moved, DataSegment{Address: s.Address - address + newAddress, Data: append([]byte{}, s.Data...)})
+	}
+	provenance := m.provenanceInRange(address, size)
+	for _, s := range moved {
+		m.RemoveBinary(s.Address-newAddress+address, uint32(len(s.Data))/m.addressUnit)
+	}
+	p := m.currentProvenance("Relocate")
+	for _, s := range moved {
+		err := m.AddBinary(s.Address, s.Data)
+		if err != nil {
+			return err
+		}
+		m.setProvenance(s.Address, m.segmentSize(&s), &p)
+	}
+	for _, r := range provenance {
+		m.setProvenance(r.Address-address+newAddress, r.Size, &r.Provenance)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	app := NewMemory()
+	app.EnableProvenance(true)
+	app.SetSourceName("app.hex")
+	err := parseIntelHex(app, ":0400000001020304F2\n:0400000508000000EF\n:00000001FF\n")
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	boot := NewMemory()
+	boot.EnableProvenance(true)
+	boot.SetSourceName("boot.txt")
+	err = boot.ParseTITXT(strings.NewReader("@0004\n05 06\nq\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+
+	m := NewMemory()
+	m.EnableProvenance(true)
+	err = m.Merge(app)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	err = m.Merge(boot)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	org := []DataSegment{{Address: 0, Data: []byte{1, 2, 3, 4, 5, 6}}}
+	if reflect.DeepEqual(m.GetDataSegments(), org) == false {
+		t.Errorf("incorrect data segments: %v", m.GetDataSegments())
+	}
+	if adr, ok := m.GetStartAddress(); adr != 0x08000000 || ok != true {
+		t.Errorf("incorrect start address: %08X", adr)
+	}
+	ranges := m.GetProvenanceRanges()
+	orgRanges := []ProvenanceRange{
+		{Address: 0, Size: 4, Provenance: Provenance{Source: "app.hex", Line: 1}},
+		{Address: 4, Size: 2, Provenance: Provenance{Source: "boot.txt", Line: 2}},
+	}
+	if reflect.DeepEqual(ranges, orgRanges) == false {
+		t.Errorf("incorrect provenance ranges: %+v", ranges)
+	}
+
+	err = m.Merge(boot)
+	if err == nil {
+		t.Error("expected overlap error")
+	}
+	if reflect.DeepEqual(m.GetDataSegments(), org) == false {
+		t.Errorf("data changed by failed merge: %v", m.GetDataSegments())
+	}
+
+	other := NewMemory()
+	other.SetAddressUnit(2, binary.LittleEndian)
+	if err = m.Merge(other); err == nil {
+		t.Error("expected address unit error")
+	}
+
+	plain := NewMemory()
+	plain.AddBinary(0x10, []byte{0x10})
+	err = m.Merge(plain)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if p, ok := m.GetProvenance(0x10); p != (Provenance{Source: "Merge"}) || ok != true {
+		t.Errorf("incorrect provenance: %+v", p)
+	}
+}
+
+func TestRelocate(t *testing.T) {
+	m := NewMemory()
+	m.EnableProvenance(true)
+	m.SetSourceName("app.txt")
+	err := m.ParseTITXT(strings.NewReader("@0100\n01 02\n03\n@0108\n04\nq\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	m.AddBinary(0x200, []byte{0xAA})
+
+	err = m.Relocate(0x100, 0x10, 0x102)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	org := []DataSegment{
+		{Address: 0x102, Data: []byte{1, 2, 3}},
+		{Address: 0x10A, Data: []byte{4}},
+		{Address: 0x200, Data: []byte{0xAA}},
+	}
+	if reflect.DeepEqual(m.GetDataSegments(), org) == false {
+		t.Errorf("incorrect data segments: %v", m.GetDataSegments())
+	}
+	ranges := m.GetProvenanceRanges()
+	orgRanges := []ProvenanceRange{
+		{Address: 0x102, Size: 2, Provenance: Provenance{Source: "app.txt", Line: 2}},
+		{Address: 0x104, Size: 1, Provenance: Provenance{Source: "app.txt", Line: 3}},
+		{Address: 0x10A, Size: 1, Provenance: Provenance{Source: "app.txt", Line: 5}},
+		{Address: 0x200, Size: 1, Provenance: Provenance{Source: "AddBinary"}},
+	}
+	if reflect.DeepEqual(ranges, orgRanges) == false {
+		t.Errorf("incorrect provenance ranges: %+v", ranges)
+	}
+
+	err = m.Relocate(0x100, 0x10, 0x1F6)
+	if err == nil {
+		t.Error("expected overlap error")
+	}
+	if reflect.DeepEqual(m.GetDataSegments(), org) == false {
+		t.Errorf("data changed by failed relocation: %v", m.GetDataSegments())
+	}
+	if err = m.Relocate(0, 0x10, 0xFFFFFFF8); err == nil {
+		t.Error("expected address space error")
+	}
+}
@@ -0,0 +1,103 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func calcMOSSum(bytes []byte) uint16 {
+	sum := uint16(0)
+	for _, b := range bytes {
+		sum += uint16(b)
+	}
+	return sum
+}
+
+func (m *Memory) mosLineParser() func(line string) error {
+	records := uint32(0)
+	return func(line string) error {
+		if len(line) == 0 {
+			return nil
+		}
+		if line[0] != ';' {
+			return newParseError(_SYNTAX_ERROR, "no semicolon char on the first line character", m.lineNum)
+		}
+		if m.eofFlag == true {
+			return newParseError(_DATA_ERROR, "data after end of file line", m.lineNum)
+		}
+		bytes, err := hex.DecodeString(line[1:])
+		if err != nil {
+			return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+		}
+		if len(bytes) < 5 {
+			return newParseError(_DATA_ERROR, "not enought data bytes", m.lineNum)
+		}
+		if (int(bytes[0]) + 5) != len(bytes) {
+			return newParseError(_DATA_ERROR, "incorrect data length", m.lineNum)
+		}
+		sum := calcMOSSum(bytes[:len(bytes)-2])
+		if last := binary.BigEndian.Uint16(bytes[len(bytes)-2:]); sum != last {
+			return newParseError(_CHECKSUM_ERROR, fmt.Sprintf("incorrect checksum (sum = %04X != %04X)", sum, last), m.lineNum)
+		}
+		adr := uint32(binary.BigEndian.Uint16(bytes[1:3]))
+		if bytes[0] == 0 {
+			if adr != records {
+				return newParseError(_RECORD_ERROR, fmt.Sprintf("incorrect record count (%d != %d)", adr, records), m.lineNum)
+			}
+			m.eofFlag = true
+			return nil
+		}
+		records++
+		return m.AddBinary(adr, bytes[3:len(bytes)-2])
+	}
+}
+
+// Method to parsing MOS Technology data and add into memory
+func (m *Memory) ParseMOS(reader io.Reader) error {
+	return m.parseLines(reader, m.mosLineParser())
+}
+
+func writeMOSLine(writer io.Writer, adr uint16, data []byte) error {
+	line := make([]byte, 5+len(data))
+	line[0] = byte(len(data))
+	binary.BigEndian.PutUint16(line[1:3], adr)
+	copy(line[3:], data)
+	binary.BigEndian.PutUint16(line[len(line)-2:], calcMOSSum(line[:len(line)-2]))
+	_, err := fmt.Fprintf(writer, ";%s\n", strings.ToUpper(hex.EncodeToString(line)))
+	return err
+}
+
+// Method to dumping MOS Technology data previously loaded into memory
+func (m *Memory) DumpMOS(writer io.Writer, lineLength byte) error {
+	step, err := m.lineStep(lineLength)
+	if err != nil {
+		return err
+	}
+	records := 0
+	for _, s := range m.dataSegments {
+		if uint64(s.Address)+uint64(m.segmentSize(s)) > 0x10000 {
+			return fmt.Errorf("data at address 0x%08X above 64K address space of MOS Technology format", s.Address)
+		}
+		records += (len(s.Data) + step - 1) / step
+	}
+	if records > 0xFFFF {
+		return errors.New("too many records for MOS Technology format")
+	}
+	for _, s := range m.dataSegments {
+		for offset := 0; offset < len(s.Data); offset += step {
+			end := offset + step
+			if end > len(s.Data) {
+				end = len(s.Data)
+			}
+			err = writeMOSLine(writer, uint16(s.Address+uint32(offset)/m.addressUnit), s.Data[offset:end])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return writeMOSLine(writer, uint16(records), []byte{})
+}
@@ -0,0 +1,53 @@
+package gohex
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMOS(t *testing.T) {
+	m := NewMemory()
+	err := m.ParseMOS(strings.NewReader(";030100010203000A\n;010103040009\n;0000020002\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0x100, Data: []byte{1, 2, 3, 4}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	err = m.ParseMOS(strings.NewReader(":030100010203000A\n;0000010001\n"))
+	checkErrorType(t, err, _SYNTAX_ERROR, "no semicolon error")
+	err = m.ParseMOS(strings.NewReader(";030100010203000B\n;0000010001\n"))
+	checkErrorType(t, err, _CHECKSUM_ERROR, "no checksum error")
+	err = m.ParseMOS(strings.NewReader(";040100010203000B\n;0000010001\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no data length error")
+	err = m.ParseMOS(strings.NewReader(";030100010203000A\n;0000020002\n"))
+	checkErrorType(t, err, _RECORD_ERROR, "no record count error")
+	err = m.ParseMOS(strings.NewReader(";030100010203000A\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no end of file line error")
+}
+
+func TestDumpMOS(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x100, []byte{1, 2, 3, 4})
+
+	buf := bytes.Buffer{}
+	err := m.DumpMOS(&buf, 3)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks := ";030100010203000A\n;010103040009\n;0000020002\n"
+	if buf.String() != oks {
+		t.Errorf("wrong mos dump:\n%v", buf.String())
+	}
+
+	m.AddBinary(0xFFFF, []byte{5, 6})
+	err = m.DumpMOS(&buf, 3)
+	if err == nil {
+		t.Error("no address space error")
+	}
+}
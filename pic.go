@@ -0,0 +1,159 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+// Type of Microchip PIC IntelHex variant
+type PICHexFormat int
+
+// Constants definitions of Microchip PIC IntelHex variants
+const (
+	INHX8M PICHexFormat = 0 // Byte addressed records without extended address (16-bit address space)
+	INHX16 PICHexFormat = 1 // Word addressed records with high byte first words
+	INHX32 PICHexFormat = 2 // Byte addressed records with extended linear address
+)
+
+func (m *Memory) parseINHX16Line(line string) error {
+	if len(line) == 0 {
+		return nil
+	}
+	if line[0] != ':' {
+		return newParseError(_SYNTAX_ERROR, "no colon char on the first line character", m.lineNum)
+	}
+	bytes, err := hex.DecodeString(line[1:])
+	if err != nil {
+		return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+	}
+	if len(bytes) < 5 {
+		return newParseError(_DATA_ERROR, "not enought data bytes", m.lineNum)
+	}
+	err = checkSum(bytes)
+	if err != nil {
+		return newParseError(_CHECKSUM_ERROR, err.Error(), m.lineNum)
+	}
+	if bytes[3] != _DATA_RECORD {
+		return m.parseIntelHexRecord(bytes)
+	}
+	if (int(bytes[0])*2 + 5) != len(bytes) {
+		return newParseError(_DATA_ERROR, "incorrect data length", m.lineNum)
+	}
+	adr := uint32(binary.BigEndian.Uint16(bytes[1:3])) * 2
+	return m.AddBinary(adr, swapWordBytes(bytes[4:len(bytes)-1]))
+}
+
+// Method to parsing Microchip PIC IntelHex variant data and add into memory (byte addressed)
+func (m *Memory) ParsePICHex(reader io.Reader, format PICHexFormat) error {
+	if m.addressUnit != 1 {
+		return errors.New("PIC hex of memory with multi-byte address unit")
+	}
+	if format == INHX16 {
+		return m.parseLines(reader, m.parseINHX16Line)
+	}
+	return m.ParseIntelHex(reader)
+}
+
+func (m *Memory) dumpShortAddressData(writer io.Writer, lineLength byte, wordRecords bool) error {
+	limit := uint64(0x10000)
+	if wordRecords {
+		limit = 0x20000
+		lineLength &^= 1
+	}
+	if lineLength == 0 {
+		return errors.New("incorrect line length")
+	}
+	for _, s := range m.dataSegments {
+		if uint64(s.Address)+uint64(len(s.Data)) > limit {
+			return errors.New("data above address space of format")
+		}
+		if wordRecords && (s.Address%2 != 0 || len(s.Data)%2 != 0) {
+			return errors.New("data segment not aligned to words")
+		}
+		for offset := 0; offset < len(s.Data); offset += int(lineLength) {
+			end := offset + int(lineLength)
+			if end > len(s.Data) {
+				end = len(s.Data)
+			}
+			adr := s.Address + uint32(offset)
+			var line []byte
+			if wordRecords {
+				line = makeWordDataLine(uint16(adr/2), s.Data[offset:end])
+			} else {
+				line = makeDataLine(uint16(adr), _DATA_RECORD, s.Data[offset:end])
+			}
+			err := writeRecordLine(writer, line)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return writeEofLine(writer)
+}
+
+// Method to dumping Microchip PIC IntelHex variant data previously loaded into memory
+func (m *Memory) DumpPICHex(writer io.Writer, format PICHexFormat, lineLength byte) error {
+	if m.addressUnit != 1 {
+		return errors.New("PIC hex of memory with multi-byte address unit")
+	}
+	switch format {
+	case INHX8M:
+		return m.dumpShortAddressData(writer, lineLength, false)
+	case INHX16:
+		return m.dumpShortAddressData(writer, lineLength, true)
+	}
+	return m.DumpIntelHex(writer, lineLength)
+}
+
+func (m *Memory) byteAt(byteAdr uint64) (byte, bool) {
+	unit := uint64(m.addressUnit)
+	if byteAdr/unit > 0xFFFFFFFF {
+		return 0, false
+	}
+	seg, offset, _ := m.findDataSegment(uint32(byteAdr / unit))
+	if seg == nil {
+		return 0, false
+	}
+	return seg.Data[uint64(offset)*unit+byteAdr%unit], true
+}
+
+// Method to getting 16-bit word (low byte first) at word address (word address is byte address divided by 2)
+func (m *Memory) GetWord(wordAdr uint32) (word uint16, ok bool) {
+	lo, loOk := m.byteAt(uint64(wordAdr) * 2)
+	hi, hiOk := m.byteAt(uint64(wordAdr)*2 + 1)
+	if loOk == false || hiOk == false {
+		return 0, false
+	}
+	return uint16(lo) | uint16(hi)<<8, true
+}
+
+// Method to set 16-bit words (low byte first) to memory at word address (words must cover whole address units)
+func (m *Memory) SetWords(wordAdr uint32, words []uint16) error {
+	byteAdr := uint64(wordAdr) * 2
+	if byteAdr%uint64(m.addressUnit) != 0 || (len(words)*2)%int(m.addressUnit) != 0 {
+		return errors.New("words not aligned to address unit")
+	}
+	data := make([]byte, len(words)*2)
+	for i, w := range words {
+		binary.LittleEndian.PutUint16(data[i*2:], w)
+	}
+	return m.SetBinary(uint32(byteAdr/uint64(m.addressUnit)), data)
+}
+
+// Method to load 16-bit words (low byte first) from memory at word address
+func (m *Memory) ToWords(wordAdr uint32, count uint32, padding uint16) []uint16 {
+	words := make([]uint16, count)
+	for i := range words {
+		adr := (uint64(wordAdr) + uint64(i)) * 2
+		b := []byte{byte(padding), byte(padding >> 8)}
+		for j := uint64(0); j < 2; j++ {
+			if v, ok := m.byteAt(adr + j); ok {
+				b[j] = v
+			}
+		}
+		words[i] = binary.LittleEndian.Uint16(b)
+	}
+	return words
+}
@@ -0,0 +1,108 @@
+package gohex
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePICHex(t *testing.T) {
+	m := NewMemory()
+	err := m.ParsePICHex(strings.NewReader(":020000040000FA\n:0400000086310A2813\n:00000001FF\n"), INHX32)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if w, ok := m.GetWord(1); w != 0x280A || ok != true {
+		t.Errorf("incorrect word: %04X", w)
+	}
+
+	err = m.ParsePICHex(strings.NewReader(":020000003186280A15\n:00000001FF\n"), INHX16)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0, Data: []byte{0x86, 0x31, 0x0A, 0x28}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	err = m.ParsePICHex(strings.NewReader(":0400000086310A2813\n:00000001FF\n"), INHX16)
+	checkErrorType(t, err, _DATA_ERROR, "no data length error")
+}
+
+func TestDumpPICHex(t *testing.T) {
+	m := NewMemory()
+	m.SetWords(0x2007, []uint16{0x3F72})
+	m.SetWords(0, []uint16{0x3186, 0x280A})
+
+	buf := bytes.Buffer{}
+	err := m.DumpPICHex(&buf, INHX8M, 16)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks := ":0400000086310A2813\n" +
+		":02400E00723FFF\n" +
+		":00000001FF\n"
+	if buf.String() != oks {
+		t.Errorf("wrong hex dump:\n%v", buf.String())
+	}
+
+	buf = bytes.Buffer{}
+	err = m.DumpPICHex(&buf, INHX16, 16)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks = ":020000003186280A15\n" +
+		":012007003F7227\n" +
+		":00000001FF\n"
+	if buf.String() != oks {
+		t.Errorf("wrong hex dump:\n%v", buf.String())
+	}
+
+	m.Clear()
+	err = m.ParsePICHex(strings.NewReader(oks), INHX16)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	words := m.ToWords(0, 3, 0x3FFF)
+	if reflect.DeepEqual(words, []uint16{0x3186, 0x280A, 0x3FFF}) == false {
+		t.Errorf("incorrect words: %v", words)
+	}
+
+	m.AddBinary(0x10000, []byte{1})
+	err = m.DumpPICHex(&bytes.Buffer{}, INHX8M, 16)
+	if err == nil {
+		t.Error("no address space error")
+	}
+}
+
+func TestPICWordsAddressUnit(t *testing.T) {
+	m := NewMemory()
+	m.SetAddressUnit(2, nil)
+	m.AddBinary(0x10, []byte{0x86, 0x31, 0x0A, 0x28})
+
+	if w, ok := m.GetWord(0x10); w != 0x3186 || ok != true {
+		t.Errorf("incorrect word: %04X", w)
+	}
+	err := m.SetWords(0x12, []uint16{0x3F72})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	words := m.ToWords(0x10, 4, 0x3FFF)
+	if reflect.DeepEqual(words, []uint16{0x3186, 0x280A, 0x3F72, 0x3FFF}) == false {
+		t.Errorf("incorrect words: %v", words)
+	}
+
+	m.Clear()
+	m.SetAddressUnit(4, nil)
+	if err = m.SetWords(1, []uint16{0x3F72}); err == nil {
+		t.Error("no alignment error")
+	}
+	if err = m.DumpPICHex(&bytes.Buffer{}, INHX8M, 16); err == nil {
+		t.Error("no address unit error")
+	}
+	if err = m.ParsePICHex(strings.NewReader(":00000001FF\n"), INHX32); err == nil {
+		t.Error("no address unit error")
+	}
+}
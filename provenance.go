@@ -0,0 +1,110 @@
+package gohex
+
+import (
+	"slices"
+	"sort"
+)
+
+// Structure with origin of data written into memory
+type Provenance struct {
+	Source string // Input source name (file name) or name of API method
+	Line   uint   // Input line number (0 when not parsed from text input)
+}
+
+// Structure with address range written by the same origin
+type ProvenanceRange struct {
+	Address    uint32     // Starting address of range
+	Size       uint32     // Range size in address units
+	Provenance Provenance // Origin of data in range
+}
+
+// Method to enable or disable provenance tracking (disabling drops collected ranges)
+func (m *Memory) EnableProvenance(enable bool) {
+	m.provenanceFlag = enable
+	m.provenance = []ProvenanceRange{}
+}
+
+// Method to set input source name recorded as provenance of subsequently parsed data
+func (m *Memory) SetSourceName(name string) {
+	m.sourceName = name
+}
+
+func (m *Memory) currentProvenance(api string) Provenance {
+	if m.parsingFlag {
+		return Provenance{Source: m.sourceName, Line: m.lineNum}
+	}
+	return Provenance{Source: api}
+}
+
+func (m *Memory) setProvenance(adr uint32, size uint32, p *Provenance) {
+	if m.provenanceFlag == false || size == 0 {
+		return
+	}
+	start := uint64(adr)
+	end := start + uint64(size)
+	first := sort.Search(len(m.provenance), func(i int) bool {
+		return uint64(m.provenance[i].Address)+uint64(m.provenance[i].Size) >= start
+	})
+	last := sort.Search(len(m.provenance), func(i int) bool {
+		return uint64(m.provenance[i].Address) > end
+	})
+	left := []ProvenanceRange{}
+	right := []ProvenanceRange{}
+	for _, r := range m.provenance[first:last] {
+		rangeStart := uint64(r.Address)
+		rangeEnd := rangeStart + uint64(r.Size)
+		if rangeStart < start {
+			left = append(left, ProvenanceRange{Address: r.Address, Size: uint32(start - rangeStart), Provenance: r.Provenance})
+		}
+		if rangeEnd > end {
+			from := max(rangeStart, end)
+			right = append(right, ProvenanceRange{Address: uint32(from), Size: uint32(rangeEnd - from), Provenance: r.Provenance})
+		}
+	}
+	if p != nil {
+		left = append(left, ProvenanceRange{Address: adr, Size: size, Provenance: *p})
+	}
+	ranges := []ProvenanceRange{}
+	for _, r := range append(left, right...) {
+		if n := len(ranges); n != 0 && ranges[n-1].Provenance == r.Provenance &&
+			uint64(ranges[n-1].Address)+uint64(ranges[n-1].Size) == uint64(r.Address) &&
+			uint64(ranges[n-1].Size)+uint64(r.Size) <= 0xFFFFFFFF {
+			ranges[n-1].Size += r.Size
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	m.provenance = slices.Replace(m.provenance, first, last, ranges...)
+}
+
+func (m *Memory) provenanceInRange(adr uint32, size uint32) []ProvenanceRange {
+	start := uint64(adr)
+	end := start + uint64(size)
+	ranges := []ProvenanceRange{}
+	for _, r := range m.provenance {
+		from := max(uint64(r.Address), start)
+		to := min(uint64(r.Address)+uint64(r.Size), end)
+		if from < to {
+			ranges = append(ranges, ProvenanceRange{Address: uint32(from), Size: uint32(to - from), Provenance: r.Provenance})
+		}
+	}
+	return ranges
+}
+
+// Method to getting origin of data at address
+func (m *Memory) GetProvenance(adr uint32) (p Provenance, ok bool) {
+	i := sort.Search(len(m.provenance), func(i int) bool {
+		return uint64(m.provenance[i].Address)+uint64(m.provenance[i].Size) > uint64(adr)
+	})
+	if i < len(m.provenance) && m.provenance[i].Address <= adr {
+		return m.provenance[i].Provenance, true
+	}
+	return Provenance{}, false
+}
+
+// Method to getting all address ranges with origin of data
+func (m *Memory) GetProvenanceRanges() []ProvenanceRange {
+	ranges := make([]ProvenanceRange, len(m.provenance))
+	copy(ranges, m.provenance)
+	return ranges
+}
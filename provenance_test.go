@@ -0,0 +1,75 @@
+package gohex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProvenance(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x100, []byte{1, 2, 3, 4})
+	if _, ok := m.GetProvenance(0x100); ok != false {
+		t.Error("unexpected provenance with disabled tracking")
+	}
+
+	m.EnableProvenance(true)
+	m.SetSourceName("app.hex")
+	err := parseIntelHex(m, ":020000041000EA\n:048000000102030472\n:04800400050607085E\n:00000001FF\n")
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if p, ok := m.GetProvenance(0x10008005); p != (Provenance{Source: "app.hex", Line: 3}) || ok != true {
+		t.Errorf("incorrect provenance: %+v", p)
+	}
+
+	m.AddBinary(0x10008008, []byte{9, 10})
+	m.SetBinary(0x10008003, []byte{0xAA, 0xBB})
+	m.RemoveBinary(0x10008009, 1)
+
+	ranges := m.GetProvenanceRanges()
+	org := []ProvenanceRange{
+		{Address: 0x10008000, Size: 3, Provenance: Provenance{Source: "app.hex", Line: 2}},
+		{Address: 0x10008003, Size: 2, Provenance: Provenance{Source: "SetBinary"}},
+		{Address: 0x10008005, Size: 3, Provenance: Provenance{Source: "app.hex", Line: 3}},
+		{Address: 0x10008008, Size: 1, Provenance: Provenance{Source: "AddBinary"}},
+	}
+	if reflect.DeepEqual(ranges, org) == false {
+		t.Errorf("incorrect provenance ranges: %+v", ranges)
+	}
+	if _, ok := m.GetProvenance(0x10008009); ok != false {
+		t.Error("unexpected provenance of removed data")
+	}
+
+	err = m.ParseTITXT(strings.NewReader("@0100\n01 02\n03\nq\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if p, ok := m.GetProvenance(0x102); p.Line != 3 || ok != true {
+		t.Errorf("incorrect provenance: %+v", p)
+	}
+}
+
+func TestProvenanceMergeRanges(t *testing.T) {
+	m := NewMemory()
+	m.EnableProvenance(true)
+	for i := uint32(0); i < 0x100; i++ {
+		m.AddBinary(i*4, []byte{1, 2, 3, 4})
+	}
+	m.SetBinary(0x10, []byte{5, 6})
+	ranges := m.GetProvenanceRanges()
+	org := []ProvenanceRange{
+		{Address: 0, Size: 0x10, Provenance: Provenance{Source: "AddBinary"}},
+		{Address: 0x10, Size: 2, Provenance: Provenance{Source: "SetBinary"}},
+		{Address: 0x12, Size: 0x3EE, Provenance: Provenance{Source: "AddBinary"}},
+	}
+	if reflect.DeepEqual(ranges, org) == false {
+		t.Errorf("incorrect provenance ranges: %+v", ranges)
+	}
+	m.RemoveBinary(0x10, 2)
+	m.AddBinary(0x10, []byte{1, 2})
+	org = []ProvenanceRange{{Address: 0, Size: 0x400, Provenance: Provenance{Source: "AddBinary"}}}
+	if reflect.DeepEqual(m.GetProvenanceRanges(), org) == false {
+		t.Errorf("incorrect provenance ranges: %+v", m.GetProvenanceRanges())
+	}
+}
@@ -0,0 +1,109 @@
+package gohex
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Type of memory region permission flags
+type RegionPermissions uint
+
+// Constants definitions of memory region permission flags
+const (
+	RegionRead  RegionPermissions = 1 << 0 // Region is readable at runtime
+	RegionWrite RegionPermissions = 1 << 1 // Region is writable at runtime
+	RegionExec  RegionPermissions = 1 << 2 // Region is executable at runtime
+	RegionLoad  RegionPermissions = 1 << 3 // Region may be initialized with image data
+)
+
+// Structure with named memory region fields
+type Region struct {
+	Name        string            // Name of region (e.g. flash, eeprom, ram)
+	Address     uint32            // Starting address of region
+	Size        uint32            // Region size in address units
+	Permissions RegionPermissions // Region permission flags
+}
+
+// Structure with memory image portion placed in forbidden area
+type RegionViolation struct {
+	Address uint32 // Starting address of violating data
+	Size    uint32 // Size of violating data in address units
+	Region  string // Name of region without load permission (empty when outside of all regions)
+}
+
+// Structure with memory region usage statistics
+type RegionUsage struct {
+	Region Region // Memory region
+	Used   uint32 // Number of address units used by image data
+}
+
+// Method to getting human readable description of violation
+func (v RegionViolation) String() string {
+	if v.Region == "" {
+		return fmt.Sprintf("%d units at 0x%08X outside of memory regions", v.Size, v.Address)
+	}
+	return fmt.Sprintf("%d units at 0x%08X in %s region without load permission", v.Size, v.Address, v.Region)
+}
+
+// Method to getting number of address units not used by image data
+func (u RegionUsage) Free() uint32 {
+	return u.Region.Size - u.Used
+}
+
+// Function to getting index of the smallest region covering address (the most specific one) or -1
+func coveringRegion(regions []Region, adr uint64) int {
+	index := -1
+	for i, r := range regions {
+		if adr < uint64(r.Address) || adr >= uint64(r.Address)+uint64(r.Size) {
+			continue
+		}
+		if index < 0 || r.Size < regions[index].Size {
+			index = i
+		}
+	}
+	return index
+}
+
+// Function to validate memory image against memory map regions (regions may be nested or overlap, the smallest covering region decides)
+func Validate(m *Memory, regions []Region) ([]RegionViolation, []RegionUsage) {
+	violations := []RegionViolation{}
+	for _, s := range m.dataSegments {
+		start := uint64(s.Address)
+		end := start + uint64(m.segmentSize(s))
+		cuts := []uint64{start, end}
+		for _, r := range regions {
+			for _, c := range []uint64{uint64(r.Address), uint64(r.Address) + uint64(r.Size)} {
+				if c > start && c < end {
+					cuts = append(cuts, c)
+				}
+			}
+		}
+		sort.Slice(cuts, func(i, j int) bool { return cuts[i] < cuts[j] })
+		for i := 0; i < len(cuts)-1; i++ {
+			if cuts[i] == cuts[i+1] {
+				continue
+			}
+			v := RegionViolation{Address: uint32(cuts[i]), Size: uint32(cuts[i+1] - cuts[i])}
+			index := coveringRegion(regions, cuts[i])
+			if index >= 0 && regions[index].Permissions&RegionLoad != 0 {
+				continue
+			}
+			if index >= 0 {
+				v.Region = regions[index].Name
+			}
+			if n := len(violations); n > 0 && violations[n-1].Region == v.Region &&
+				uint64(violations[n-1].Address)+uint64(violations[n-1].Size) == cuts[i] {
+				violations[n-1].Size += v.Size
+				continue
+			}
+			violations = append(violations, v)
+		}
+	}
+
+	usage := []RegionUsage{}
+	for _, r := range regions {
+		used, _ := m.Usage(r.Address, r.Size)
+		usage = append(usage, RegionUsage{Region: r, Used: used})
+	}
+	return violations, usage
+}
@@ -0,0 +1,70 @@
+package gohex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	regions := []Region{
+		{Name: "ram", Address: 0x20000000, Size: 0x1000, Permissions: RegionRead | RegionWrite | RegionExec},
+		{Name: "flash", Address: 0x08000000, Size: 0x1000, Permissions: RegionRead | RegionExec | RegionLoad},
+		{Name: "config", Address: 0x08001000, Size: 0x10, Permissions: RegionRead | RegionLoad},
+	}
+	m := NewMemory()
+	m.AddBinary(0x08000FFE, []byte{1, 2, 3, 4})
+	m.AddBinary(0x0800100E, []byte{5, 6, 7, 8})
+	m.AddBinary(0x20000010, []byte{9})
+
+	violations, usage := Validate(m, regions)
+	org := []RegionViolation{
+		{Address: 0x08001010, Size: 2},
+		{Address: 0x20000010, Size: 1, Region: "ram"},
+	}
+	if reflect.DeepEqual(violations, org) == false {
+		t.Errorf("incorrect violations: %v", violations)
+	}
+	if len(usage) != 3 {
+		t.Errorf("incorrect number of usage entries: %v", len(usage))
+	}
+	if usage[0].Region.Name != "ram" || usage[0].Used != 1 {
+		t.Errorf("incorrect usage: %+v", usage[0])
+	}
+	if usage[1].Region.Name != "flash" || usage[1].Used != 2 || usage[1].Free() != 0xFFE {
+		t.Errorf("incorrect usage: %+v", usage[1])
+	}
+	if usage[2].Region.Name != "config" || usage[2].Used != 4 {
+		t.Errorf("incorrect usage: %+v", usage[2])
+	}
+
+	m.Clear()
+	m.AddBinary(0x08000000, []byte{1, 2, 3, 4})
+	violations, _ = Validate(m, regions)
+	if len(violations) != 0 {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+}
+
+func TestValidateNestedRegions(t *testing.T) {
+	regions := []Region{
+		{Name: "flash", Address: 0x0, Size: 0x1000, Permissions: RegionRead | RegionExec | RegionLoad},
+		{Name: "otp", Address: 0x900, Size: 0x100, Permissions: RegionRead},
+		{Name: "config", Address: 0x980, Size: 0x10, Permissions: RegionRead | RegionLoad},
+	}
+	m := NewMemory()
+	m.AddBinary(0x8FE, make([]byte, 0x94))
+	m.AddBinary(0xFFE, []byte{1, 2, 3, 4})
+
+	violations, usage := Validate(m, regions)
+	org := []RegionViolation{
+		{Address: 0x900, Size: 0x80, Region: "otp"},
+		{Address: 0x990, Size: 0x2, Region: "otp"},
+		{Address: 0x1000, Size: 2},
+	}
+	if reflect.DeepEqual(violations, org) == false {
+		t.Errorf("incorrect violations: %v", violations)
+	}
+	if usage[0].Used != 0x96 || usage[1].Used != 0x92 || usage[2].Used != 0x10 {
+		t.Errorf("incorrect usage: %+v", usage)
+	}
+}
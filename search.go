@@ -0,0 +1,74 @@
+package gohex
+
+import (
+	"bytes"
+)
+
+func matchPattern(data []byte, pattern []byte, mask []byte) bool {
+	for i, p := range pattern {
+		msk := byte(0xFF)
+		if i < len(mask) {
+			msk = mask[i]
+		}
+		if data[i]&msk != p&msk {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function to get contiguous data runs (adjacent segments joined)
+func (m *Memory) contiguousRuns() []DataSegment {
+	runs := []DataSegment{}
+	for _, s := range m.dataSegments {
+		if n := len(runs); n > 0 && uint64(runs[n-1].Address)+uint64(len(runs[n-1].Data))/uint64(m.addressUnit) == uint64(s.Address) {
+			runs[n-1].Data = append(append([]byte{}, runs[n-1].Data...), s.Data...)
+			continue
+		}
+		runs = append(runs, DataSegment{Address: s.Address, Data: s.Data})
+	}
+	return runs
+}
+
+func (m *Memory) find(pattern []byte, mask []byte, all bool) []uint32 {
+	found := []uint32{}
+	if len(pattern) == 0 {
+		return found
+	}
+	unit := m.addressUnit
+	for _, r := range m.contiguousRuns() {
+		for i := 0; i+len(pattern) <= len(r.Data); i++ {
+			if mask == nil {
+				n := bytes.Index(r.Data[i:], pattern)
+				if n < 0 {
+					break
+				}
+				i += n
+			} else if matchPattern(r.Data[i:], pattern, mask) == false {
+				continue
+			}
+			if uint32(i)%unit != 0 {
+				continue
+			}
+			found = append(found, r.Address+uint32(i)/unit)
+			if all == false {
+				return found
+			}
+		}
+	}
+	return found
+}
+
+// Method to find address of first occurrence of byte pattern (mask bytes select compared bits, nil mask means exact match)
+func (m *Memory) Find(pattern []byte, mask []byte) (adr uint32, ok bool) {
+	found := m.find(pattern, mask, false)
+	if len(found) == 0 {
+		return 0, false
+	}
+	return found[0], true
+}
+
+// Method to find addresses of all occurrences of byte pattern (mask bytes select compared bits, nil mask means exact match)
+func (m *Memory) FindAll(pattern []byte, mask []byte) []uint32 {
+	return m.find(pattern, mask, true)
+}
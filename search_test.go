@@ -0,0 +1,34 @@
+package gohex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x100, []byte{'v', '1', '.', '2', 0, 'v', '1'})
+	m.AddBinary(0x200, []byte{'.', '3'})
+	m.AddBinary(0x107, []byte{'.', '4'})
+
+	if a, ok := m.Find([]byte("v1."), nil); a != 0x100 || ok != true {
+		t.Errorf("incorrect address: %08X", a)
+	}
+	if a, ok := m.Find([]byte("v2."), nil); ok != false {
+		t.Errorf("incorrect address: %08X", a)
+	}
+
+	found := m.FindAll([]byte("v1."), nil)
+	if reflect.DeepEqual(found, []uint32{0x100, 0x105}) == false {
+		t.Errorf("incorrect addresses: %v", found)
+	}
+
+	found = m.FindAll([]byte{'v', '1', '.', 0}, []byte{0xFF, 0xFF, 0xFF, 0x00})
+	if reflect.DeepEqual(found, []uint32{0x100, 0x105}) == false {
+		t.Errorf("incorrect addresses: %v", found)
+	}
+	found = m.FindAll([]byte{'.', '3'}, []byte{0xFF, 0xF0})
+	if reflect.DeepEqual(found, []uint32{0x102, 0x107, 0x200}) == false {
+		t.Errorf("incorrect addresses: %v", found)
+	}
+}
@@ -0,0 +1,154 @@
+package gohex
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// Structure with settings of signed memory content
+type SignatureOptions struct {
+	Address uint32 // Starting address of signed range
+	Size    uint32 // Size of signed range (0 means that all segments and start address are signed)
+	Padding byte   // Value of byte used to fill gaps of signed range
+}
+
+// Method to getting canonical serialization of memory content covered by signature
+func (m *Memory) signedData(opts SignatureOptions) []byte {
+	if opts.Size != 0 {
+		return m.ToBinary(opts.Address, opts.Size, opts.Padding)
+	}
+	data := []byte{byte(m.addressUnit)}
+	if m.startFlag {
+		data = append(data, 1)
+	} else {
+		data = append(data, 0)
+	}
+	data = binary.BigEndian.AppendUint32(data, m.startAddress)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(m.dataSegments)))
+	for _, s := range m.dataSegments {
+		data = binary.BigEndian.AppendUint32(data, s.Address)
+		data = binary.BigEndian.AppendUint32(data, uint32(len(s.Data)))
+		data = append(data, s.Data...)
+	}
+	return data
+}
+
+func ecdsaHash(curve elliptic.Curve) crypto.Hash {
+	switch curve.Params().BitSize {
+	case 384:
+		return crypto.SHA384
+	case 521:
+		return crypto.SHA512
+	}
+	return crypto.SHA256
+}
+
+// Function to getting size of signature made with key (ed25519 or ECDSA)
+func SignatureSize(key crypto.PublicKey) (uint32, error) {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.SignatureSize, nil
+	case *ecdsa.PublicKey:
+		return uint32((k.Curve.Params().BitSize+7)/8) * 2, nil
+	}
+	return 0, errors.New("unsupported key type")
+}
+
+// Method to sign memory content with ed25519 or ECDSA key (ECDSA signature is fixed size r and s pair)
+func (m *Memory) Sign(signer crypto.Signer, opts SignatureOptions) ([]byte, error) {
+	data := m.signedData(opts)
+	switch k := signer.Public().(type) {
+	case ed25519.PublicKey:
+		return signer.Sign(rand.Reader, data, crypto.Hash(0))
+	case *ecdsa.PublicKey:
+		hash := ecdsaHash(k.Curve)
+		h := hash.New()
+		h.Write(data)
+		der, err := signer.Sign(rand.Reader, h.Sum(nil), hash)
+		if err != nil {
+			return nil, err
+		}
+		sig := struct{ R, S *big.Int }{}
+		if _, err = asn1.Unmarshal(der, &sig); err != nil {
+			return nil, err
+		}
+		size, _ := SignatureSize(k)
+		result := make([]byte, size)
+		sig.R.FillBytes(result[:size/2])
+		sig.S.FillBytes(result[size/2:])
+		return result, nil
+	}
+	return nil, errors.New("unsupported key type")
+}
+
+// Method to verify signature of memory content with ed25519 or ECDSA public key
+func (m *Memory) VerifySignature(key crypto.PublicKey, signature []byte, opts SignatureOptions) error {
+	size, err := SignatureSize(key)
+	if err != nil {
+		return err
+	}
+	if uint32(len(signature)) != size {
+		return errors.New("incorrect signature size")
+	}
+	data := m.signedData(opts)
+	ok := false
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(k, data, signature)
+	case *ecdsa.PublicKey:
+		h := ecdsaHash(k.Curve).New()
+		h.Write(data)
+		r := new(big.Int).SetBytes(signature[:size/2])
+		s := new(big.Int).SetBytes(signature[size/2:])
+		ok = ecdsa.Verify(k, h.Sum(nil), r, s)
+	}
+	if ok == false {
+		return errors.New("incorrect signature")
+	}
+	return nil
+}
+
+func (m *Memory) withoutSignature(key crypto.PublicKey, address uint32) (*Memory, uint32, error) {
+	size, err := SignatureSize(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if size%m.addressUnit != 0 {
+		return nil, 0, errors.New("signature size not aligned to address unit")
+	}
+	unsigned := m.Clone()
+	unsigned.RemoveBinary(address, size/m.addressUnit)
+	return unsigned, size, nil
+}
+
+// Method to sign memory content and embed signature at address (signature area is excluded from signed content)
+func (m *Memory) EmbedSignature(signer crypto.Signer, address uint32, opts SignatureOptions) error {
+	unsigned, _, err := m.withoutSignature(signer.Public(), address)
+	if err != nil {
+		return err
+	}
+	signature, err := unsigned.Sign(signer, opts)
+	if err != nil {
+		return err
+	}
+	return m.SetBinary(address, signature)
+}
+
+// Method to verify signature embedded at address with ed25519 or ECDSA public key
+func (m *Memory) VerifyEmbeddedSignature(key crypto.PublicKey, address uint32, opts SignatureOptions) error {
+	unsigned, size, err := m.withoutSignature(key, address)
+	if err != nil {
+		return err
+	}
+	if gaps := m.Gaps(address, size/m.addressUnit); len(gaps) != 0 {
+		return errors.New("no embedded signature")
+	}
+	return unsigned.VerifySignature(key, m.ToBinary(address, size/m.addressUnit, 0), opts)
+}
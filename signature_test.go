@@ -0,0 +1,96 @@
+package gohex
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+)
+
+func testSigners(t *testing.T) []crypto.Signer {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal("unexpected error: ", err.Error())
+	}
+	return []crypto.Signer{ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)), p256, p384}
+}
+
+func TestSignature(t *testing.T) {
+	for _, signer := range testSigners(t) {
+		m := NewMemory()
+		m.AddBinary(0x100, []byte{1, 2, 3, 4})
+		m.AddBinary(0x200, []byte{5, 6})
+		m.SetStartAddress(0x100)
+
+		sig, err := m.Sign(signer, SignatureOptions{})
+		if err != nil {
+			t.Fatal("unexpected error: ", err.Error())
+		}
+		size, _ := SignatureSize(signer.Public())
+		if uint32(len(sig)) != size {
+			t.Errorf("incorrect signature size: %d", len(sig))
+		}
+		if err = m.VerifySignature(signer.Public(), sig, SignatureOptions{}); err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+
+		c := m.Clone()
+		c.SetStartAddress(0x200)
+		if err = c.VerifySignature(signer.Public(), sig, SignatureOptions{}); err == nil {
+			t.Error("no start address signature error")
+		}
+		c = m.Clone()
+		c.AddBinary(0x300, []byte{0xFF})
+		if err = c.VerifySignature(signer.Public(), sig, SignatureOptions{}); err == nil {
+			t.Error("no segments signature error")
+		}
+
+		opts := SignatureOptions{Address: 0x100, Size: 0x100, Padding: 0xFF}
+		sig, err = m.Sign(signer, opts)
+		if err != nil {
+			t.Fatal("unexpected error: ", err.Error())
+		}
+		if err = c.VerifySignature(signer.Public(), sig, opts); err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		c.AddBinary(0x104, []byte{0xFF})
+		if err = c.VerifySignature(signer.Public(), sig, opts); err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		c.SetBinary(0x100, []byte{0})
+		if err = c.VerifySignature(signer.Public(), sig, opts); err == nil {
+			t.Error("no range signature error")
+		}
+		if err = m.VerifySignature(signer.Public(), sig[1:], opts); err == nil {
+			t.Error("no signature size error")
+		}
+	}
+}
+
+func TestEmbeddedSignature(t *testing.T) {
+	for _, signer := range testSigners(t) {
+		m := NewMemory()
+		m.AddBinary(0x100, []byte{1, 2, 3, 4})
+
+		err := m.EmbedSignature(signer, 0x1000, SignatureOptions{})
+		if err != nil {
+			t.Fatal("unexpected error: ", err.Error())
+		}
+		if err = m.VerifyEmbeddedSignature(signer.Public(), 0x1000, SignatureOptions{}); err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		m.SetBinary(0x101, []byte{0})
+		if err = m.VerifyEmbeddedSignature(signer.Public(), 0x1000, SignatureOptions{}); err == nil {
+			t.Error("no signature error")
+		}
+		if err = m.VerifyEmbeddedSignature(signer.Public(), 0x2000, SignatureOptions{}); err == nil {
+			t.Error("no embedded signature error")
+		}
+	}
+}
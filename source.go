@@ -0,0 +1,136 @@
+package gohex
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"strings"
+)
+
+// Structure with settings of source code arrays generation
+type SourceOptions struct {
+	Name      string // Identifier of generated array (prefix of macros and per-segment arrays)
+	Package   string // Package name of generated Go source
+	LineWidth byte   // Number of bytes per line
+	Segments  bool   // Generate separate array per data segment instead of one padded array
+	Padding   byte   // Value of byte used to fill gaps in padded array
+}
+
+func (opts *SourceOptions) check() error {
+	if token.IsIdentifier(opts.Name) == false {
+		return errors.New("incorrect array identifier")
+	}
+	if opts.LineWidth == 0 {
+		return errors.New("incorrect line length")
+	}
+	return nil
+}
+
+func writeByteLines(writer io.Writer, data []byte, lineWidth byte, indent string) error {
+	for offset := 0; offset < len(data); offset += int(lineWidth) {
+		end := offset + int(lineWidth)
+		if end > len(data) {
+			end = len(data)
+		}
+		fields := make([]string, 0, end-offset)
+		for _, b := range data[offset:end] {
+			fields = append(fields, fmt.Sprintf("0x%02X,", b))
+		}
+		_, err := fmt.Fprintf(writer, "%s%s\n", indent, strings.Join(fields, " "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCArray(writer io.Writer, name string, adr uint32, data []byte, lineWidth byte) error {
+	macro := strings.ToUpper(name)
+	_, err := fmt.Fprintf(writer, "#define %s_ADDRESS 0x%08XUL\n#define %s_SIZE %dUL\n\nstatic const uint8_t %s[%s_SIZE] = {\n",
+		macro, adr, macro, len(data), name, macro)
+	if err != nil {
+		return err
+	}
+	err = writeByteLines(writer, data, lineWidth, "\t")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "};\n\n")
+	return err
+}
+
+// Method to dumping memory range as C header with const uint8_t arrays
+func (m *Memory) DumpCHeader(writer io.Writer, address uint32, size uint32, opts SourceOptions) error {
+	err := opts.check()
+	if err != nil {
+		return err
+	}
+	guard := strings.ToUpper(opts.Name) + "_H"
+	_, err = fmt.Fprintf(writer, "#ifndef %s\n#define %s\n\n#include <stdint.h>\n\n", guard, guard)
+	if err != nil {
+		return err
+	}
+	if opts.Segments {
+		for i, s := range m.segmentsInRange(address, size) {
+			err = writeCArray(writer, fmt.Sprintf("%s_seg%d", opts.Name, i), s.Address, s.Data, opts.LineWidth)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		err = writeCArray(writer, opts.Name, address, m.ToBinary(address, size, opts.Padding), opts.LineWidth)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(writer, "#endif /* %s */\n", guard)
+	return err
+}
+
+// Method to dumping memory range as Go source with []byte or map of data segments
+func (m *Memory) DumpGoSource(writer io.Writer, address uint32, size uint32, opts SourceOptions) error {
+	err := opts.check()
+	if err != nil {
+		return err
+	}
+	if token.IsIdentifier(opts.Package) == false {
+		return errors.New("incorrect package name")
+	}
+	_, err = fmt.Fprintf(writer, "// Code generated by gohex. DO NOT EDIT.\n\npackage %s\n\n", opts.Package)
+	if err != nil {
+		return err
+	}
+	if opts.Segments {
+		_, err = fmt.Fprintf(writer, "// Data segments of %s indexed by address\nvar %s = map[uint32][]byte{\n", opts.Name, opts.Name)
+		if err != nil {
+			return err
+		}
+		for _, s := range m.segmentsInRange(address, size) {
+			_, err = fmt.Fprintf(writer, "\t0x%08X: {\n", s.Address)
+			if err != nil {
+				return err
+			}
+			err = writeByteLines(writer, s.Data, opts.LineWidth, "\t\t")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(writer, "\t},\n")
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		_, err = fmt.Fprintf(writer, "// Load address of %s\nconst %sAddress = 0x%08X\n\n// Binary data of %s\nvar %s = []byte{\n",
+			opts.Name, opts.Name, address, opts.Name, opts.Name)
+		if err != nil {
+			return err
+		}
+		err = writeByteLines(writer, m.ToBinary(address, size, opts.Padding), opts.LineWidth, "\t")
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(writer, "}\n")
+	return err
+}
@@ -0,0 +1,76 @@
+package gohex
+
+import (
+	"bytes"
+	"go/format"
+	"testing"
+)
+
+func TestDumpCHeader(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x100, []byte{1, 2, 3})
+	m.AddBinary(0x104, []byte{4})
+
+	buf := bytes.Buffer{}
+	err := m.DumpCHeader(&buf, 0x100, 5, SourceOptions{Name: "boot", LineWidth: 4, Padding: 0xFF})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks := "#ifndef BOOT_H\n#define BOOT_H\n\n#include <stdint.h>\n\n" +
+		"#define BOOT_ADDRESS 0x00000100UL\n#define BOOT_SIZE 5UL\n\n" +
+		"static const uint8_t boot[BOOT_SIZE] = {\n\t0x01, 0x02, 0x03, 0xFF,\n\t0x04,\n};\n\n" +
+		"#endif /* BOOT_H */\n"
+	if buf.String() != oks {
+		t.Errorf("wrong c header dump:\n%v", buf.String())
+	}
+
+	buf = bytes.Buffer{}
+	err = m.DumpCHeader(&buf, 0x101, 4, SourceOptions{Name: "boot", LineWidth: 4, Segments: true})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks = "#ifndef BOOT_H\n#define BOOT_H\n\n#include <stdint.h>\n\n" +
+		"#define BOOT_SEG0_ADDRESS 0x00000101UL\n#define BOOT_SEG0_SIZE 2UL\n\n" +
+		"static const uint8_t boot_seg0[BOOT_SEG0_SIZE] = {\n\t0x02, 0x03,\n};\n\n" +
+		"#define BOOT_SEG1_ADDRESS 0x00000104UL\n#define BOOT_SEG1_SIZE 1UL\n\n" +
+		"static const uint8_t boot_seg1[BOOT_SEG1_SIZE] = {\n\t0x04,\n};\n\n" +
+		"#endif /* BOOT_H */\n"
+	if buf.String() != oks {
+		t.Errorf("wrong c header dump:\n%v", buf.String())
+	}
+
+	err = m.DumpCHeader(&buf, 0x100, 5, SourceOptions{Name: "1boot", LineWidth: 4})
+	if err == nil {
+		t.Error("no identifier error")
+	}
+}
+
+func TestDumpGoSource(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x100, []byte{1, 2, 3})
+	m.AddBinary(0x104, []byte{4})
+
+	for _, segments := range []bool{false, true} {
+		buf := bytes.Buffer{}
+		err := m.DumpGoSource(&buf, 0x100, 5, SourceOptions{Name: "Boot", Package: "firmware", LineWidth: 2, Segments: segments})
+		if err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			t.Error("unexpected error: ", err.Error())
+		}
+		if bytes.Equal(src, buf.Bytes()) == false {
+			t.Errorf("unformatted go source dump:\n%v", buf.String())
+		}
+	}
+
+	buf := bytes.Buffer{}
+	m.DumpGoSource(&buf, 0x100, 5, SourceOptions{Name: "Boot", Package: "firmware", LineWidth: 8})
+	oks := "// Code generated by gohex. DO NOT EDIT.\n\npackage firmware\n\n" +
+		"// Load address of Boot\nconst BootAddress = 0x00000100\n\n" +
+		"// Binary data of Boot\nvar Boot = []byte{\n\t0x01, 0x02, 0x03, 0x00, 0x04,\n}\n"
+	if buf.String() != oks {
+		t.Errorf("wrong go source dump:\n%v", buf.String())
+	}
+}
@@ -0,0 +1,23 @@
+package gohex
+
+// Method to find first free block of given size (aligned to align units) in address range
+func (m *Memory) FindFreeBlock(address uint32, size uint32, blockSize uint32, align uint32) (adr uint32, ok bool) {
+	if align == 0 {
+		align = 1
+	}
+	for _, g := range m.Gaps(address, size) {
+		start := (uint64(g.Address) + uint64(align) - 1) / uint64(align) * uint64(align)
+		if start+uint64(blockSize) <= uint64(g.Address)+uint64(g.Size) {
+			return uint32(start), true
+		}
+	}
+	return 0, false
+}
+
+// Method to compute number of used and free units in address range
+func (m *Memory) Usage(address uint32, size uint32) (used uint32, free uint32) {
+	for _, g := range m.Gaps(address, size) {
+		free += g.Size
+	}
+	return size - free, free
+}
@@ -0,0 +1,51 @@
+package gohex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGaps(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x02, []byte{2, 3})
+	m.AddBinary(0x08, []byte{8, 9, 10, 11})
+
+	gaps := m.Gaps(0, 0x10)
+	org := []AddressRange{{Address: 0x00, Size: 2}, {Address: 0x04, Size: 4}, {Address: 0x0C, Size: 4}}
+	if reflect.DeepEqual(gaps, org) == false {
+		t.Errorf("incorrect gaps: %v", gaps)
+	}
+	gaps = m.Gaps(0x08, 4)
+	if len(gaps) != 0 {
+		t.Errorf("incorrect gaps: %v", gaps)
+	}
+}
+
+func TestFindFreeBlock(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x02, []byte{2, 3})
+	m.AddBinary(0x08, []byte{8, 9, 10, 11})
+
+	if a, ok := m.FindFreeBlock(0, 0x20, 3, 1); a != 0x04 || ok != true {
+		t.Errorf("incorrect free block: %v %v", a, ok)
+	}
+	if a, ok := m.FindFreeBlock(0, 0x20, 4, 8); a != 0x10 || ok != true {
+		t.Errorf("incorrect free block: %v %v", a, ok)
+	}
+	if a, ok := m.FindFreeBlock(0, 0x0C, 5, 0); ok != false {
+		t.Errorf("incorrect free block: %v %v", a, ok)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x02, []byte{2, 3})
+	m.AddBinary(0x08, []byte{8, 9, 10, 11})
+
+	if used, free := m.Usage(0, 0x10); used != 6 || free != 10 {
+		t.Errorf("incorrect usage: %v %v", used, free)
+	}
+	if used, free := m.Usage(0x03, 6); used != 2 || free != 4 {
+		t.Errorf("incorrect usage: %v %v", used, free)
+	}
+}
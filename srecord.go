@@ -0,0 +1,86 @@
+package gohex
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Function to getting number of address bytes of S-record type (0 means reserved type)
+func srecordAddressSize(recordType byte) int {
+	switch recordType {
+	case '0', '1', '5', '9':
+		return 2
+	case '2', '6', '8':
+		return 3
+	case '3', '7':
+		return 4
+	}
+	return 0
+}
+
+func calcSRecordSum(bytes []byte) byte {
+	sum := byte(0)
+	for _, b := range bytes {
+		sum += b
+	}
+	return ^sum
+}
+
+func (m *Memory) srecordLineParser() func(line string) error {
+	records := uint32(0)
+	return func(line string) error {
+		if len(line) == 0 {
+			return nil
+		}
+		if line[0] != 'S' || len(line) < 2 {
+			return newParseError(_SYNTAX_ERROR, "no S char on the first line character", m.lineNum)
+		}
+		if m.eofFlag == true {
+			return newParseError(_DATA_ERROR, "data after end of file line", m.lineNum)
+		}
+		adrSize := srecordAddressSize(line[1])
+		if adrSize == 0 {
+			return newParseError(_RECORD_ERROR, "incorrect record type", m.lineNum)
+		}
+		bytes, err := hex.DecodeString(line[2:])
+		if err != nil {
+			return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+		}
+		if len(bytes) < adrSize+2 {
+			return newParseError(_DATA_ERROR, "not enought data bytes", m.lineNum)
+		}
+		if int(bytes[0])+1 != len(bytes) {
+			return newParseError(_DATA_ERROR, "incorrect data length", m.lineNum)
+		}
+		sum := calcSRecordSum(bytes[:len(bytes)-1])
+		if last := bytes[len(bytes)-1]; sum != last {
+			return newParseError(_CHECKSUM_ERROR, fmt.Sprintf("incorrect checksum (sum = %02X != %02X)", sum, last), m.lineNum)
+		}
+		adr := uint32(0)
+		for _, b := range bytes[1 : 1+adrSize] {
+			adr = adr<<8 | uint32(b)
+		}
+		data := bytes[1+adrSize : len(bytes)-1]
+		switch line[1] {
+		case '0':
+			return nil
+		case '5', '6':
+			if adr != records {
+				return newParseError(_RECORD_ERROR, fmt.Sprintf("incorrect record count (%d != %d)", adr, records), m.lineNum)
+			}
+			return nil
+		case '7', '8', '9':
+			m.SetStartAddress(adr)
+			m.eofFlag = true
+			return nil
+		}
+		records++
+		return m.AddBinary(adr, data)
+	}
+}
+
+// Method to parsing Motorola S-record data and add into memory
+func (m *Memory) ParseSRecord(reader io.Reader) error {
+	return m.parseLines(reader, m.srecordLineParser())
+}
@@ -0,0 +1,51 @@
+package gohex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSRecord(t *testing.T) {
+	m := NewMemory()
+	err := m.ParseSRecord(strings.NewReader("S00600004844521B\nS107100001020304DE\nS5030001FB\nS9031000EC\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0x1000, Data: []byte{1, 2, 3, 4}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	if adr, ok := m.GetStartAddress(); ok == false || adr != 0x1000 {
+		t.Errorf("incorrect start address: %08X", adr)
+	}
+
+	err = m.ParseSRecord(strings.NewReader("S2061010040506CA\r\nS3062000000007D2\r\nS5030002FA\r\nS70520000000DA\r\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	segs := m.GetDataSegments()
+	p2 := []DataSegment{{Address: 0x101004, Data: []byte{5, 6}}, {Address: 0x20000000, Data: []byte{7}}}
+	if reflect.DeepEqual(segs, p2) == false {
+		t.Errorf("incorrect segments: %v != %v", segs, p2)
+	}
+	if adr, ok := m.GetStartAddress(); ok == false || adr != 0x20000000 {
+		t.Errorf("incorrect start address: %08X", adr)
+	}
+
+	err = m.ParseSRecord(strings.NewReader(":107100001020304DE\nS9031000EC\n"))
+	checkErrorType(t, err, _SYNTAX_ERROR, "no S char error")
+	err = m.ParseSRecord(strings.NewReader("S407100001020304DE\nS9031000EC\n"))
+	checkErrorType(t, err, _RECORD_ERROR, "no record type error")
+	err = m.ParseSRecord(strings.NewReader("S107100001020304DF\nS9031000EC\n"))
+	checkErrorType(t, err, _CHECKSUM_ERROR, "no checksum error")
+	err = m.ParseSRecord(strings.NewReader("S108100001020304DE\nS9031000EC\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no data length error")
+	err = m.ParseSRecord(strings.NewReader("S107100001020304DE\nS5030003F9\nS9031000EC\n"))
+	checkErrorType(t, err, _RECORD_ERROR, "no record count error")
+	err = m.ParseSRecord(strings.NewReader("S107100001020304DE\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no end of file line error")
+	err = m.ParseSRecord(strings.NewReader("S9031000EC\nS107100001020304DE\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no data after end of file error")
+}
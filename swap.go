@@ -0,0 +1,72 @@
+package gohex
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Type of policy of handling words only partially filled with data
+type PartialWordPolicy int
+
+// Constants definitions of partial word handling policies
+const (
+	PartialWordError PartialWordPolicy = 0 // Return error and leave memory untouched
+	PartialWordPad   PartialWordPolicy = 1 // Fill missing bytes with padding byte and swap
+	PartialWordSkip  PartialWordPolicy = 2 // Leave partial words untouched
+)
+
+// Method to swap order of bytes within 2, 4 or 8 byte words in address range (words counted from range start)
+func (m *Memory) SwapBytes(address uint32, size uint32, wordSize uint32, policy PartialWordPolicy, padding byte) error {
+	if wordSize != 2 && wordSize != 4 && wordSize != 8 {
+		return errors.New("incorrect word size")
+	}
+	if size%wordSize != 0 {
+		return errors.New("range size not aligned to word size")
+	}
+	if m.addressUnit != 1 {
+		return errors.New("bytes swapping of memory with multi-byte address unit")
+	}
+
+	words := []uint64{}
+	partial := map[uint64]bool{}
+	cursor := &segmentCursor{segs: m.dataSegments}
+	for _, s := range m.segmentsInRange(address, size) {
+		first := (uint64(s.Address) - uint64(address)) / uint64(wordSize)
+		last := (uint64(s.Address) + uint64(len(s.Data)) - 1 - uint64(address)) / uint64(wordSize)
+		for w := first; w <= last; w++ {
+			start := uint64(address) + w*uint64(wordSize)
+			if len(words) != 0 && words[len(words)-1] == start {
+				continue
+			}
+			words = append(words, start)
+			for i := uint64(0); i < uint64(wordSize); i++ {
+				if _, ok := cursor.get(start + i); ok == false {
+					partial[start] = true
+					break
+				}
+			}
+		}
+	}
+
+	if policy == PartialWordError && len(partial) != 0 {
+		for _, start := range words {
+			if partial[start] {
+				return fmt.Errorf("partial word at address 0x%08X", start)
+			}
+		}
+	}
+	for _, start := range words {
+		if partial[start] {
+			if policy != PartialWordPad {
+				continue
+			}
+			m.fillRange(uint32(start), wordSize, padding)
+		}
+		seg, offset, _ := m.findDataSegment(uint32(start))
+		data := seg.Data[offset : offset+wordSize]
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package gohex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSwapBytes(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x00, []byte{0, 1, 2, 3, 4, 5, 6, 7})
+
+	err := m.SwapBytes(0, 8, 2, PartialWordError, 0)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	data := m.ToBinary(0, 8, 0xFF)
+	org := []byte{1, 0, 3, 2, 5, 4, 7, 6}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+
+	err = m.SwapBytes(0, 8, 4, PartialWordError, 0)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	data = m.ToBinary(0, 8, 0xFF)
+	org = []byte{2, 3, 0, 1, 6, 7, 4, 5}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+
+	m.Clear()
+	m.AddBinary(0x01, []byte{1, 2, 3, 4, 5})
+	m.AddBinary(0x08, []byte{8, 9, 10, 11, 12, 13, 14, 15})
+
+	err = m.SwapBytes(0, 8, 4, PartialWordError, 0)
+	if err == nil {
+		t.Error("no partial word error")
+	}
+	data = m.ToBinary(0, 8, 0xFF)
+	org = []byte{0xFF, 1, 2, 3, 4, 5, 0xFF, 0xFF}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+
+	err = m.SwapBytes(0, 16, 8, PartialWordSkip, 0)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	data = m.ToBinary(0, 16, 0xFF)
+	org = []byte{0xFF, 1, 2, 3, 4, 5, 0xFF, 0xFF, 15, 14, 13, 12, 11, 10, 9, 8}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+
+	err = m.SwapBytes(0, 8, 4, PartialWordPad, 0xEE)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	data = m.ToBinary(0, 8, 0xFF)
+	org = []byte{3, 2, 1, 0xEE, 0xEE, 0xEE, 5, 4}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+
+	if err = m.SwapBytes(0, 6, 4, PartialWordSkip, 0); err == nil {
+		t.Error("no range alignment error")
+	}
+}
@@ -0,0 +1,246 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Constants definitions of Extended Tektronix record types
+const (
+	_TEK_EXT_DATA_RECORD   byte = '6' // Record with data bytes
+	_TEK_EXT_SYMBOL_RECORD byte = '3' // Record with symbol table
+	_TEK_EXT_END_RECORD    byte = '8' // Record with start address and end of file indicator
+)
+
+func nibbleSum(bytes []byte) byte {
+	sum := byte(0)
+	for _, b := range bytes {
+		sum += (b >> 4) + (b & 0x0F)
+	}
+	return sum
+}
+
+func tekExtCharValue(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'A' && c <= 'Z':
+		return c - 'A' + 10, true
+	case c == '$':
+		return 36, true
+	case c == '%':
+		return 37, true
+	case c == '.':
+		return 38, true
+	case c == '_':
+		return 39, true
+	case c >= 'a' && c <= 'z':
+		return c - 'a' + 40, true
+	}
+	return 0, false
+}
+
+func tekExtSum(record string) (byte, error) {
+	sum := byte(0)
+	for i := 0; i < len(record); i++ {
+		v, ok := tekExtCharValue(record[i])
+		if ok == false {
+			return 0, errors.New("incorrect record character")
+		}
+		sum += v
+	}
+	return sum, nil
+}
+
+func (m *Memory) parseTektronixLine(line string) error {
+	if len(line) == 0 {
+		return nil
+	}
+	if line[0] != '/' {
+		return newParseError(_SYNTAX_ERROR, "no slash char on the first line character", m.lineNum)
+	}
+	if m.eofFlag == true {
+		return newParseError(_DATA_ERROR, "data after end of file line", m.lineNum)
+	}
+	bytes, err := hex.DecodeString(line[1:])
+	if err != nil {
+		return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+	}
+	if len(bytes) < 4 {
+		return newParseError(_DATA_ERROR, "not enought data bytes", m.lineNum)
+	}
+	if sum := nibbleSum(bytes[0:3]); sum != bytes[3] {
+		return newParseError(_CHECKSUM_ERROR, fmt.Sprintf("incorrect header checksum (sum = %02X != %02X)", sum, bytes[3]), m.lineNum)
+	}
+	adr := uint32(binary.BigEndian.Uint16(bytes[0:2]))
+	size := int(bytes[2])
+	if size == 0 {
+		if len(bytes) != 4 {
+			return newParseError(_DATA_ERROR, "incorrect data length", m.lineNum)
+		}
+		m.SetStartAddress(adr)
+		m.eofFlag = true
+		return nil
+	}
+	if len(bytes) != size+5 {
+		return newParseError(_DATA_ERROR, "incorrect data length", m.lineNum)
+	}
+	data := bytes[4 : 4+size]
+	if sum := nibbleSum(data); sum != bytes[4+size] {
+		return newParseError(_CHECKSUM_ERROR, fmt.Sprintf("incorrect data checksum (sum = %02X != %02X)", sum, bytes[4+size]), m.lineNum)
+	}
+	return m.AddBinary(adr, data)
+}
+
+// Method to parsing Tektronix hex data and add into memory
+func (m *Memory) ParseTektronix(reader io.Reader) error {
+	return m.parseLines(reader, m.parseTektronixLine)
+}
+
+// Method to dumping Tektronix hex data previously loaded into memory
+func (m *Memory) DumpTektronix(writer io.Writer, lineLength byte) error {
+	step, err := m.lineStep(lineLength)
+	if err != nil {
+		return err
+	}
+	if m.startFlag && m.startAddress > 0xFFFF {
+		return errors.New("start address above address space of format")
+	}
+	for _, s := range m.dataSegments {
+		if uint64(s.Address)+uint64(m.segmentSize(s)) > 0x10000 {
+			return errors.New("data above address space of format")
+		}
+		for offset := 0; offset < len(s.Data); offset += step {
+			end := offset + step
+			if end > len(s.Data) {
+				end = len(s.Data)
+			}
+			data := s.Data[offset:end]
+			header := []byte{0, 0, byte(len(data))}
+			binary.BigEndian.PutUint16(header, uint16(s.Address+uint32(offset)/m.addressUnit))
+			_, err = fmt.Fprintf(writer, "/%s%02X%s%02X\n", strings.ToUpper(hex.EncodeToString(header)), nibbleSum(header),
+				strings.ToUpper(hex.EncodeToString(data)), nibbleSum(data))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	header := []byte{0, 0, 0}
+	binary.BigEndian.PutUint16(header, uint16(m.startAddress))
+	_, err = fmt.Fprintf(writer, "/%s%02X\n", strings.ToUpper(hex.EncodeToString(header)), nibbleSum(header))
+	return err
+}
+
+func (m *Memory) parseExtendedTektronixLine(line string) error {
+	if len(line) == 0 {
+		return nil
+	}
+	if line[0] != '%' {
+		return newParseError(_SYNTAX_ERROR, "no percent char on the first line character", m.lineNum)
+	}
+	if m.eofFlag == true {
+		return newParseError(_DATA_ERROR, "data after end of file line", m.lineNum)
+	}
+	record := line[1:]
+	if len(record) < 6 {
+		return newParseError(_DATA_ERROR, "not enought record characters", m.lineNum)
+	}
+	size, err := strconv.ParseUint(record[0:2], 16, 8)
+	if err != nil {
+		return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+	}
+	if int(size) != len(record) {
+		return newParseError(_DATA_ERROR, "incorrect record length", m.lineNum)
+	}
+	checksum, err := strconv.ParseUint(record[3:5], 16, 8)
+	if err != nil {
+		return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+	}
+	sum, err := tekExtSum(record[0:3] + record[5:])
+	if err != nil {
+		return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+	}
+	if sum != byte(checksum) {
+		return newParseError(_CHECKSUM_ERROR, fmt.Sprintf("incorrect checksum (sum = %02X != %02X)", sum, checksum), m.lineNum)
+	}
+
+	recordType := record[2]
+	if recordType == _TEK_EXT_SYMBOL_RECORD {
+		return nil
+	}
+	if recordType != _TEK_EXT_DATA_RECORD && recordType != _TEK_EXT_END_RECORD {
+		return newParseError(_RECORD_ERROR, "unknown record type", m.lineNum)
+	}
+	adrLen, err := strconv.ParseUint(record[5:6], 16, 8)
+	if err != nil {
+		return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+	}
+	if adrLen == 0 {
+		adrLen = 16
+	}
+	if 6+int(adrLen) > len(record) {
+		return newParseError(_DATA_ERROR, "not enought record characters", m.lineNum)
+	}
+	adr, err := strconv.ParseUint(record[6:6+adrLen], 16, 32)
+	if err != nil {
+		return newParseError(_RECORD_ERROR, "incorrect address field", m.lineNum)
+	}
+	data, err := hex.DecodeString(record[6+adrLen:])
+	if err != nil {
+		return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+	}
+	if recordType == _TEK_EXT_END_RECORD {
+		if len(data) != 0 {
+			return newParseError(_RECORD_ERROR, "data in termination record", m.lineNum)
+		}
+		m.SetStartAddress(uint32(adr))
+		m.eofFlag = true
+		return nil
+	}
+	return m.AddBinary(uint32(adr), data)
+}
+
+// Method to parsing Extended Tektronix hex data and add into memory
+func (m *Memory) ParseExtendedTektronix(reader io.Reader) error {
+	return m.parseLines(reader, m.parseExtendedTektronixLine)
+}
+
+func writeExtendedTektronixLine(writer io.Writer, recordType byte, adr uint32, data []byte) error {
+	body := fmt.Sprintf("8%08X%s", adr, strings.ToUpper(hex.EncodeToString(data)))
+	head := fmt.Sprintf("%02X%c", len(body)+5, recordType)
+	sum, err := tekExtSum(head + body)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "%%%s%02X%s\n", head, sum, body)
+	return err
+}
+
+// Method to dumping Extended Tektronix hex data previously loaded into memory
+func (m *Memory) DumpExtendedTektronix(writer io.Writer, lineLength byte) error {
+	if lineLength > 120 {
+		return errors.New("incorrect line length")
+	}
+	step, err := m.lineStep(lineLength)
+	if err != nil {
+		return err
+	}
+	for _, s := range m.dataSegments {
+		for offset := 0; offset < len(s.Data); offset += step {
+			end := offset + step
+			if end > len(s.Data) {
+				end = len(s.Data)
+			}
+			err = writeExtendedTektronixLine(writer, _TEK_EXT_DATA_RECORD, s.Address+uint32(offset)/m.addressUnit, s.Data[offset:end])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return writeExtendedTektronixLine(writer, _TEK_EXT_END_RECORD, m.startAddress, []byte{})
+}
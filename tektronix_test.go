@@ -0,0 +1,101 @@
+package gohex
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTektronix(t *testing.T) {
+	m := NewMemory()
+	err := m.ParseTektronix(strings.NewReader("/01000203010203\n/010201040303\n/00100001\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0x100, Data: []byte{1, 2, 3}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	if a, ok := m.GetStartAddress(); a != 0x0010 || ok != true {
+		t.Errorf("incorrect start address: %08X", a)
+	}
+
+	err = m.ParseTektronix(strings.NewReader("01000203010203\n/00000000\n"))
+	checkErrorType(t, err, _SYNTAX_ERROR, "no slash error")
+	err = m.ParseTektronix(strings.NewReader("/01000204010203\n/00000000\n"))
+	checkErrorType(t, err, _CHECKSUM_ERROR, "no header checksum error")
+	err = m.ParseTektronix(strings.NewReader("/01000203010204\n/00000000\n"))
+	checkErrorType(t, err, _CHECKSUM_ERROR, "no data checksum error")
+	err = m.ParseTektronix(strings.NewReader("/010003040102\n/00000000\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no data length error")
+	err = m.ParseTektronix(strings.NewReader("/01000203010203\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no end of file line error")
+}
+
+func TestDumpTektronix(t *testing.T) {
+	m := NewMemory()
+	m.SetStartAddress(0x10)
+	m.AddBinary(0x100, []byte{1, 2, 3})
+
+	buf := bytes.Buffer{}
+	err := m.DumpTektronix(&buf, 2)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks := "/01000203010203\n/010201040303\n/00100001\n"
+	if buf.String() != oks {
+		t.Errorf("wrong tektronix dump:\n%v", buf.String())
+	}
+
+	m.AddBinary(0x10000, []byte{1})
+	err = m.DumpTektronix(&buf, 2)
+	if err == nil {
+		t.Error("no address space error")
+	}
+}
+
+func TestExtendedTektronix(t *testing.T) {
+	m := NewMemory()
+	err := m.ParseExtendedTektronix(strings.NewReader("%126158000001000102\n%0E81F800000010\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0x100, Data: []byte{1, 2}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	if a, ok := m.GetStartAddress(); a != 0x10 || ok != true {
+		t.Errorf("incorrect start address: %08X", a)
+	}
+
+	m.AddBinary(0x12345678, []byte{0xAB, 0xCD, 0xEF})
+	buf := bytes.Buffer{}
+	err = m.DumpExtendedTektronix(&buf, 2)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	dump := buf.String()
+	m.Clear()
+	err = m.ParseExtendedTektronix(strings.NewReader(dump))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if len(m.GetDataSegments()) != 2 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+	seg = m.GetDataSegments()[1]
+	p = DataSegment{Address: 0x12345678, Data: []byte{0xAB, 0xCD, 0xEF}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	err = m.ParseExtendedTektronix(strings.NewReader("%126168000001000102\n%0E81F800000010\n"))
+	checkErrorType(t, err, _CHECKSUM_ERROR, "no checksum error")
+	err = m.ParseExtendedTektronix(strings.NewReader("%136158000001000102\n%0E81F800000010\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no record length error")
+	err = m.ParseExtendedTektronix(strings.NewReader("%1261580000010001#2\n%0E81F800000010\n"))
+	checkErrorType(t, err, _SYNTAX_ERROR, "no syntax error")
+}
@@ -0,0 +1,94 @@
+package gohex
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func (m *Memory) titxtLineParser() func(line string) error {
+	adr := uint32(0)
+	adrFlag := false
+	return func(line string) error {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			return nil
+		}
+		if m.eofFlag == true {
+			return newParseError(_DATA_ERROR, "data after end of file line", m.lineNum)
+		}
+		switch line[0] {
+		case '@':
+			a, err := strconv.ParseUint(line[1:], 16, 32)
+			if err != nil {
+				return newParseError(_SYNTAX_ERROR, "incorrect address line", m.lineNum)
+			}
+			adr = uint32(a)
+			adrFlag = true
+			return nil
+		case 'q', 'Q':
+			if len(line) != 1 {
+				return newParseError(_SYNTAX_ERROR, "incorrect end of file line", m.lineNum)
+			}
+			m.eofFlag = true
+			return nil
+		}
+		if adrFlag == false {
+			return newParseError(_RECORD_ERROR, "data line without address line", m.lineNum)
+		}
+		data := []byte{}
+		for _, field := range strings.Fields(line) {
+			if len(field) != 2 {
+				return newParseError(_SYNTAX_ERROR, "incorrect data byte field", m.lineNum)
+			}
+			b, err := hex.DecodeString(field)
+			if err != nil {
+				return newParseError(_SYNTAX_ERROR, err.Error(), m.lineNum)
+			}
+			data = append(data, b[0])
+		}
+		err := m.AddBinary(adr, data)
+		if err != nil {
+			return err
+		}
+		adr += uint32(len(data)) / m.addressUnit
+		return nil
+	}
+}
+
+// Method to parsing TI-TXT data and add into memory
+func (m *Memory) ParseTITXT(reader io.Reader) error {
+	return m.parseLines(reader, m.titxtLineParser())
+}
+
+// Method to dumping TI-TXT data previously loaded into memory
+func (m *Memory) DumpTITXT(writer io.Writer, lineLength byte) error {
+	step, err := m.lineStep(lineLength)
+	if err != nil {
+		return err
+	}
+	for _, s := range m.dataSegments {
+		_, err = fmt.Fprintf(writer, "@%04X\n", s.Address)
+		if err != nil {
+			return err
+		}
+		for offset := 0; offset < len(s.Data); offset += step {
+			end := offset + step
+			if end > len(s.Data) {
+				end = len(s.Data)
+			}
+			fields := make([]string, 0, end-offset)
+			for _, b := range s.Data[offset:end] {
+				fields = append(fields, fmt.Sprintf("%02X", b))
+			}
+			_, err = fmt.Fprintf(writer, "%s\n", strings.Join(fields, " "))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	_, err = fmt.Fprintf(writer, "q\n")
+	return err
+}
@@ -0,0 +1,84 @@
+package gohex
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTITXT(t *testing.T) {
+	m := NewMemory()
+	err := m.ParseTITXT(strings.NewReader("@F000\n31 40 00 03\nB2 40\n@FFFE\n00 F0\nq\n"))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if len(m.GetDataSegments()) != 2 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0xF000, Data: []byte{0x31, 0x40, 0x00, 0x03, 0xB2, 0x40}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	seg = m.GetDataSegments()[1]
+	p = DataSegment{Address: 0xFFFE, Data: []byte{0x00, 0xF0}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+
+	err = m.ParseTITXT(strings.NewReader("@F0G0\n31 40\nq\n"))
+	checkErrorType(t, err, _SYNTAX_ERROR, "no address line error")
+	err = m.ParseTITXT(strings.NewReader("31 40\nq\n"))
+	checkErrorType(t, err, _RECORD_ERROR, "no missing address line error")
+	err = m.ParseTITXT(strings.NewReader("@F000\n314\nq\n"))
+	checkErrorType(t, err, _SYNTAX_ERROR, "no data byte error")
+	err = m.ParseTITXT(strings.NewReader("@F000\n31 40\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no end of file line error")
+	err = m.ParseTITXT(strings.NewReader("@F000\n31 40\n@F001\n00\nq\n"))
+	checkErrorType(t, err, _DATA_ERROR, "no segments overlap error")
+}
+
+func TestDumpTITXT(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0xF000, []byte{1, 2, 3, 4, 5})
+	m.AddBinary(0x10000, []byte{6})
+
+	buf := bytes.Buffer{}
+	err := m.DumpTITXT(&buf, 4)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks := "@F000\n01 02 03 04\n05\n@10000\n06\nq\n"
+	if buf.String() != oks {
+		t.Errorf("wrong ti-txt dump:\n%v", buf.String())
+	}
+}
+
+func TestDumpTITXTAddressUnit(t *testing.T) {
+	m := NewMemory()
+	m.SetAddressUnit(2, nil)
+	m.AddBinary(0x100, []byte{1, 2, 3, 4, 5, 6})
+
+	buf := bytes.Buffer{}
+	err := m.DumpTITXT(&buf, 3)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	oks := "@0100\n01 02\n03 04\n05 06\nq\n"
+	if buf.String() != oks {
+		t.Errorf("wrong ti-txt dump:\n%v", buf.String())
+	}
+	p := NewMemory()
+	p.SetAddressUnit(2, nil)
+	err = p.ParseTITXT(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if reflect.DeepEqual(p.GetDataSegments(), m.GetDataSegments()) == false {
+		t.Errorf("incorrect parsed segments: %v", p.GetDataSegments())
+	}
+	if err = m.DumpTITXT(&buf, 1); err == nil {
+		t.Error("no line length error")
+	}
+}
@@ -0,0 +1,64 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Structure with saved memory content used by transactions
+type memorySnapshot struct {
+	dataSegments []*DataSegment
+	startAddress uint32
+	startFlag    bool
+	addressUnit  uint32
+	byteOrder    binary.ByteOrder
+	provenance   []ProvenanceRange
+}
+
+func (m *Memory) snapshot() memorySnapshot {
+	provenance := make([]ProvenanceRange, len(m.provenance))
+	copy(provenance, m.provenance)
+	return memorySnapshot{
+		dataSegments: m.copySegments(),
+		startAddress: m.startAddress,
+		startFlag:    m.startFlag,
+		addressUnit:  m.addressUnit,
+		byteOrder:    m.byteOrder,
+		provenance:   provenance,
+	}
+}
+
+// Method to begin transaction of memory edits (transactions can be nested)
+func (m *Memory) Begin() {
+	m.transactions = append(m.transactions, m.snapshot())
+}
+
+// Method to commit memory edits of the innermost transaction
+func (m *Memory) Commit() error {
+	if len(m.transactions) == 0 {
+		return errors.New("no transaction in progress")
+	}
+	m.transactions = m.transactions[:len(m.transactions)-1]
+	return nil
+}
+
+// Method to rollback memory edits of the innermost transaction
+func (m *Memory) Rollback() error {
+	if len(m.transactions) == 0 {
+		return errors.New("no transaction in progress")
+	}
+	s := m.transactions[len(m.transactions)-1]
+	m.transactions = m.transactions[:len(m.transactions)-1]
+	m.dataSegments = s.dataSegments
+	m.startAddress = s.startAddress
+	m.startFlag = s.startFlag
+	m.addressUnit = s.addressUnit
+	m.byteOrder = s.byteOrder
+	m.provenance = s.provenance
+	return nil
+}
+
+// Method to check if any transaction is in progress
+func (m *Memory) InTransaction() bool {
+	return len(m.transactions) != 0
+}
@@ -0,0 +1,79 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestTransactions(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0x00, []byte{0, 1, 2, 3})
+	m.AddBinary(0x08, []byte{8, 9, 10, 11})
+
+	if err := m.Commit(); err == nil {
+		t.Error("no transaction error")
+	}
+	if err := m.Rollback(); err == nil {
+		t.Error("no transaction error")
+	}
+
+	org := m.ToBinary(0, 12, 0xFF)
+
+	m.Begin()
+	m.SetBinary(0x02, []byte{102, 103, 4, 5, 6, 7, 108})
+	m.RemoveBinary(0x0A, 2)
+	m.SetStartAddress(0x1234)
+
+	m.Begin()
+	m.AddBinary(0x20, []byte{1})
+	if err := m.Commit(); err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if m.InTransaction() != true {
+		t.Error("incorrect transaction state")
+	}
+
+	if err := m.Rollback(); err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	if m.InTransaction() != false {
+		t.Error("incorrect transaction state")
+	}
+	data := m.ToBinary(0, 12, 0xFF)
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+	if len(m.GetDataSegments()) != 2 {
+		t.Errorf("incorrect number of data segments: %v", len(m.GetDataSegments()))
+	}
+	if _, ok := m.GetStartAddress(); ok != false {
+		t.Error("incorrect start address state")
+	}
+
+	m.Begin()
+	m.SetBinary(0x00, []byte{0xAA})
+	m.Commit()
+	if data := m.ToBinary(0, 1, 0xFF); data[0] != 0xAA {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+}
+
+func TestTransactionAddressUnit(t *testing.T) {
+	m := NewMemory()
+	m.AddBinary(0, []byte{1, 2, 3})
+
+	m.Begin()
+	m.Clear()
+	err := m.SetAddressUnit(2, binary.BigEndian)
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	m.Rollback()
+	if size, order := m.GetAddressUnit(); size != 1 || order != binary.LittleEndian {
+		t.Errorf("incorrect address unit: %d %v", size, order)
+	}
+	if data := m.ToBinary(0, 3, 0); reflect.DeepEqual(data, []byte{1, 2, 3}) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+}
@@ -0,0 +1,54 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Constants definitions of UF2 block structure
+const (
+	_UF2_BLOCK_SIZE           = 512        // Size of UF2 block
+	_UF2_MAX_PAYLOAD          = 476        // Maximum size of UF2 block payload
+	_UF2_MAGIC_END     uint32 = 0x0AB16F30 // Final UF2 block magic word
+	_UF2_FLAG_NOT_MAIN        = 0x00000001 // Block not intended for main flash flag
+)
+
+// Method to parsing UF2 (USB Flashing Format) blocks and add into memory
+func (m *Memory) ParseUF2(reader io.Reader) error {
+	m.Clear()
+	m.parsingFlag = true
+	defer func() { m.parsingFlag = false }()
+	block := make([]byte, _UF2_BLOCK_SIZE)
+	for index := 0; ; index++ {
+		_, err := io.ReadFull(reader, block)
+		if err == io.EOF {
+			if index == 0 {
+				return errors.New("no UF2 blocks")
+			}
+			return nil
+		} else if err == io.ErrUnexpectedEOF {
+			return fmt.Errorf("truncated UF2 block %d", index)
+		} else if err != nil {
+			return err
+		}
+		if binary.LittleEndian.Uint32(block[0:]) != _UF2_MAGIC_START0 ||
+			binary.LittleEndian.Uint32(block[4:]) != _UF2_MAGIC_START1 ||
+			binary.LittleEndian.Uint32(block[_UF2_BLOCK_SIZE-4:]) != _UF2_MAGIC_END {
+			return fmt.Errorf("incorrect magic of UF2 block %d", index)
+		}
+		if binary.LittleEndian.Uint32(block[8:])&_UF2_FLAG_NOT_MAIN != 0 {
+			continue
+		}
+		adr := binary.LittleEndian.Uint32(block[12:])
+		size := binary.LittleEndian.Uint32(block[16:])
+		if size > _UF2_MAX_PAYLOAD {
+			return fmt.Errorf("incorrect payload size of UF2 block %d", index)
+		}
+		err = m.AddBinary(adr, append([]byte{}, block[32:32+size]...))
+		if err != nil {
+			return err
+		}
+	}
+}
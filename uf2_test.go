@@ -0,0 +1,53 @@
+package gohex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func makeUF2Block(flags uint32, adr uint32, data []byte) []byte {
+	block := make([]byte, _UF2_BLOCK_SIZE)
+	binary.LittleEndian.PutUint32(block[0:], _UF2_MAGIC_START0)
+	binary.LittleEndian.PutUint32(block[4:], _UF2_MAGIC_START1)
+	binary.LittleEndian.PutUint32(block[8:], flags)
+	binary.LittleEndian.PutUint32(block[12:], adr)
+	binary.LittleEndian.PutUint32(block[16:], uint32(len(data)))
+	copy(block[32:], data)
+	binary.LittleEndian.PutUint32(block[_UF2_BLOCK_SIZE-4:], _UF2_MAGIC_END)
+	return block
+}
+
+func TestParseUF2(t *testing.T) {
+	file := append(makeUF2Block(0, 0x2000, []byte{1, 2, 3, 4}), makeUF2Block(0, 0x2004, []byte{5, 6})...)
+	file = append(file, makeUF2Block(_UF2_FLAG_NOT_MAIN, 0x0, []byte{7})...)
+
+	m := NewMemory()
+	err := m.ParseUF2(bytes.NewReader(file))
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	segs := m.GetDataSegments()
+	p := []DataSegment{{Address: 0x2000, Data: []byte{1, 2, 3, 4, 5, 6}}}
+	if reflect.DeepEqual(segs, p) == false {
+		t.Errorf("incorrect segments: %v != %v", segs, p)
+	}
+
+	if err = m.ParseUF2(bytes.NewReader(file[:600])); err == nil {
+		t.Error("no truncated block error")
+	}
+	bad := makeUF2Block(0, 0x2000, []byte{1})
+	bad[_UF2_BLOCK_SIZE-1] = 0
+	if err = m.ParseUF2(bytes.NewReader(bad)); err == nil {
+		t.Error("no magic error")
+	}
+	bad = makeUF2Block(0, 0x2000, []byte{1})
+	binary.LittleEndian.PutUint32(bad[16:], _UF2_MAX_PAYLOAD+1)
+	if err = m.ParseUF2(bytes.NewReader(bad)); err == nil {
+		t.Error("no payload size error")
+	}
+	if err = m.ParseUF2(bytes.NewReader(nil)); err == nil {
+		t.Error("no empty input error")
+	}
+}
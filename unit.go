@@ -0,0 +1,67 @@
+package gohex
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Method to set number of bytes per address (1, 2 or 4) and byte order of multi-byte units
+func (m *Memory) SetAddressUnit(size uint32, order binary.ByteOrder) error {
+	if size != 1 && size != 2 && size != 4 {
+		return errors.New("incorrect address unit size")
+	}
+	if len(m.dataSegments) != 0 && size != m.addressUnit {
+		return errors.New("address unit size change of non empty memory")
+	}
+	if order == nil {
+		order = binary.LittleEndian
+	}
+	m.addressUnit = size
+	m.byteOrder = order
+	return nil
+}
+
+// Method to getting number of bytes per address and byte order of multi-byte units
+func (m *Memory) GetAddressUnit() (size uint32, order binary.ByteOrder) {
+	return m.addressUnit, m.byteOrder
+}
+
+func (m *Memory) decodeUnit(data []byte) uint32 {
+	switch m.addressUnit {
+	case 2:
+		return uint32(m.byteOrder.Uint16(data))
+	case 4:
+		return m.byteOrder.Uint32(data)
+	}
+	return uint32(data[0])
+}
+
+func (m *Memory) encodeUnit(data []byte, value uint32) {
+	switch m.addressUnit {
+	case 2:
+		m.byteOrder.PutUint16(data, uint16(value))
+	case 4:
+		m.byteOrder.PutUint32(data, value)
+	default:
+		data[0] = byte(value)
+	}
+}
+
+// Method to getting value of address unit at address (decoded with configured byte order)
+func (m *Memory) GetValue(adr uint32) (value uint32, ok bool) {
+	seg, offset, _ := m.findDataSegment(adr)
+	if seg == nil {
+		return 0, false
+	}
+	offset *= m.addressUnit
+	return m.decodeUnit(seg.Data[offset : offset+m.addressUnit]), true
+}
+
+// Method to set values of address units to memory (encoded with configured byte order)
+func (m *Memory) SetValues(adr uint32, values []uint32) error {
+	data := make([]byte, uint32(len(values))*m.addressUnit)
+	for i, v := range values {
+		m.encodeUnit(data[uint32(i)*m.addressUnit:], v)
+	}
+	return m.SetBinary(adr, data)
+}
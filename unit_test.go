@@ -0,0 +1,90 @@
+package gohex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestAddressUnit(t *testing.T) {
+	m := NewMemory()
+	if err := m.SetAddressUnit(3, binary.BigEndian); err == nil {
+		t.Error("no address unit size error")
+	}
+	if err := m.SetAddressUnit(2, binary.BigEndian); err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+
+	err := parseIntelHex(m, ":020000040001F9\n:048000000102030472\n:00000001FF\n")
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0x18000, Data: []byte{1, 2, 3, 4}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	if v, ok := m.GetValue(0x18001); v != 0x0304 || ok != true {
+		t.Errorf("incorrect value: %04X", v)
+	}
+	if err := m.SetAddressUnit(4, binary.BigEndian); err == nil {
+		t.Error("no non empty memory error")
+	}
+
+	err = m.AddBinary(0x18002, []byte{5, 6, 7, 8})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	err = m.AddBinary(0x18004, []byte{9})
+	checkErrorType(t, err, _DATA_ERROR, "no address unit alignment error")
+
+	m.SetValues(0x18003, []uint32{0xAABB, 0xCCDD})
+	data := m.ToBinary(0x17FFF, 6, 0xFF)
+	org := []byte{0xFF, 0xFF, 1, 2, 3, 4, 5, 6, 0xAA, 0xBB, 0xCC, 0xDD}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+
+	m.RemoveBinary(0x18001, 2)
+	data = m.ToBinary(0x18000, 5, 0xFF)
+	org = []byte{1, 2, 0xFF, 0xFF, 0xFF, 0xFF, 0xAA, 0xBB, 0xCC, 0xDD}
+	if reflect.DeepEqual(data, org) == false {
+		t.Errorf("incorrect binary data: %v", data)
+	}
+
+	buf := bytes.Buffer{}
+	m.DumpIntelHex(&buf, 5)
+	oks := ":020000040001F9\n" +
+		":0280000001027B\n" +
+		":04800300AABBCCDD6B\n" +
+		":00000001FF\n"
+	if buf.String() != oks {
+		t.Errorf("wrong hex dump:\n%v", buf.String())
+	}
+
+	err = parseIntelHex(m, ":0380000001020377\n:00000001FF\n")
+	checkErrorType(t, err, _DATA_ERROR, "no address unit alignment error")
+}
+
+func TestSetBinaryAddressUnit(t *testing.T) {
+	m := NewMemory()
+	m.SetAddressUnit(2, nil)
+	err := m.SetBinary(0, []byte{1, 2, 3})
+	checkErrorType(t, err, _DATA_ERROR, "no address unit alignment error")
+	if len(m.GetDataSegments()) != 0 {
+		t.Errorf("data set by misaligned write: %v", m.GetDataSegments())
+	}
+	err = m.SetBinary(0, []byte{1, 2, 3, 4})
+	if err != nil {
+		t.Error("unexpected error: ", err.Error())
+	}
+	seg := m.GetDataSegments()[0]
+	p := DataSegment{Address: 0, Data: []byte{1, 2, 3, 4}}
+	if reflect.DeepEqual(seg, p) == false {
+		t.Errorf("incorrect segment: %v != %v", seg, p)
+	}
+	if err := m.AddBinary(4, []byte{1, 2, 3}); err == nil {
+		t.Error("no alignment error")
+	}
+}